@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"reminder-app/controller/protocol"
+	v1 "reminder-app/controller/protocol/v1"
+	"reminder-app/handler"
+)
+
+//go:embed client.tmpl
+var clientTemplate string
+
+// protocolPkg is where tygo generates ../types/protocol.ts from.
+var protocolPkg = reflect.TypeOf(protocol.Reminder{}).PkgPath()
+
+// versionedTypes are the protocol types that API versions keep their own
+// copies of. The client refers to a copy by the protocol type's name, so long
+// as the two still encode the same.
+var versionedTypes = map[reflect.Type]reflect.Type{
+	reflect.TypeOf(v1.Reminder{}):                   reflect.TypeOf(protocol.Reminder{}),
+	reflect.TypeOf(v1.ReminderPage{}):               reflect.TypeOf(protocol.ReminderPage{}),
+	reflect.TypeOf(v1.CreateReminderRequest{}):      reflect.TypeOf(protocol.CreateReminderRequest{}),
+	reflect.TypeOf(v1.UpdateReminderRequest{}):      reflect.TypeOf(protocol.UpdateReminderRequest{}),
+	reflect.TypeOf(v1.BatchRemindersRequest{}):      reflect.TypeOf(protocol.BatchRemindersRequest{}),
+	reflect.TypeOf(v1.BatchReminderOperation{}):     reflect.TypeOf(protocol.BatchReminderOperation{}),
+	reflect.TypeOf(v1.BatchReminderResult{}):        reflect.TypeOf(protocol.BatchReminderResult{}),
+	reflect.TypeOf(v1.BatchRemindersResponse{}):     reflect.TypeOf(protocol.BatchRemindersResponse{}),
+	reflect.TypeOf(v1.ImportedReminder{}):           reflect.TypeOf(protocol.ImportedReminder{}),
+	reflect.TypeOf(v1.ImportICSResponse{}):          reflect.TypeOf(protocol.ImportICSResponse{}),
+	reflect.TypeOf(v1.ContactMethod{}):              reflect.TypeOf(protocol.ContactMethod{}),
+	reflect.TypeOf(v1.CreateContactMethodRequest{}): reflect.TypeOf(protocol.CreateContactMethodRequest{}),
+	reflect.TypeOf(v1.UpdateContactMethodRequest{}): reflect.TypeOf(protocol.UpdateContactMethodRequest{}),
+}
+
+type ClientData struct {
+	// Prefix is the path of the API version the client calls.
+	Prefix    string
+	Imports   []string
+	Endpoints []Endpoint
+}
+
+type Endpoint struct {
+	Name    string
+	Summary string
+	Params  []string
+	Result  string
+	// ErrorName is the type of the statuses in ErrorStatuses.
+	ErrorName     string
+	ErrorStatuses string
+	Request       []string
+	// Call is the helper that makes the request, taking Request, then Args,
+	// and resolving to a result of CallType.
+	Call     string
+	CallType string
+	Args     []string
+}
+
+func main() {
+	out := flag.String("out", "../frontend/src/api/client.ts", "file to write the client to")
+	flag.Parse()
+
+	var buf bytes.Buffer
+	if err := generate(&buf); err != nil {
+		log.Fatalf("Failed to generate client: %v", err)
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+	fmt.Printf("Generated client in %s\n", *out)
+}
+
+// generate writes a client for the routes of the latest API version.
+func generate(w io.Writer) error {
+	data, err := newClientData(handler.LatestVersion())
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("client").Parse(clientTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl.Execute(w, data)
+}
+
+func newClientData(version handler.APIVersion) (*ClientData, error) {
+	g := &generator{imports: map[string]bool{"ErrorResponse": true}}
+	data := &ClientData{Prefix: version.Prefix}
+	for _, route := range version.Routes() {
+		endpoint, err := g.endpoint(route)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", route.Method, route.Path, err)
+		}
+		data.Endpoints = append(data.Endpoints, endpoint)
+	}
+	for name := range g.imports {
+		data.Imports = append(data.Imports, name)
+	}
+	slices.Sort(data.Imports)
+	return data, nil
+}
+
+type generator struct {
+	imports map[string]bool
+}
+
+func (g *generator) endpoint(route handler.Route) (Endpoint, error) {
+	var params, request []string
+	url := route.Path
+	for _, name := range route.PathParams() {
+		params = append(params, name+": number")
+		url = strings.Replace(url, ":"+name, "${"+name+"}", 1)
+	}
+	request = append(request, fmt.Sprintf("method: %q", route.Method), "url: `"+url+"`")
+
+	switch {
+	case route.Body != nil:
+		body, err := g.tsType(reflect.TypeOf(route.Body))
+		if err != nil {
+			return Endpoint{}, err
+		}
+		data := "body"
+		if route.Uploads != nil {
+			body += " | Blob"
+			data = "body instanceof Blob ? upload(body) : body"
+		}
+		if route.OptionalBody {
+			params = append(params, "body: "+body+" = {}")
+		} else {
+			params = append(params, "body: "+body)
+		}
+		request = append(request, "data: "+data)
+	case route.Uploads != nil:
+		params = append(params, "file: Blob")
+		request = append(request, "data: upload(file)")
+	}
+
+	if route.Query != nil {
+		query, err := g.tsType(reflect.TypeOf(route.Query))
+		if err != nil {
+			return Endpoint{}, err
+		}
+		if hasRequiredField(reflect.TypeOf(route.Query)) {
+			params = append(params, "query: "+query)
+		} else {
+			params = append(params, "query: "+query+" = {}")
+		}
+		request = append(request, "params: query")
+	}
+
+	var headers []string
+	if route.BodyType != "" {
+		headers = append(headers, fmt.Sprintf(`"Content-Type": %q`, route.BodyType))
+	}
+	if route.ChecksETag() {
+		params = append(params, "ifMatch?: string")
+		headers = append(headers, `...(ifMatch ? { "If-Match": ifMatch } : {})`)
+	}
+	if headers != nil {
+		request = append(request, "headers: { "+strings.Join(headers, ", ")+" }")
+	}
+
+	// Streams resolve once they end, handing each event to onEvent.
+	call, callType, args := "send", "", []string(nil)
+	if route.Events != nil {
+		event, err := g.tsType(reflect.TypeOf(route.Events))
+		if err != nil {
+			return Endpoint{}, err
+		}
+		params = append(params, "onEvent: (event: "+event+") => void", "signal?: AbortSignal")
+		call, callType, args = "stream", event, []string{"onEvent", "signal"}
+	}
+
+	result := "void"
+	if route.Response != nil {
+		response, err := g.tsType(reflect.TypeOf(route.Response))
+		if err != nil {
+			return Endpoint{}, err
+		}
+		result = response
+	}
+	if route.Downloads != nil {
+		if route.Response == nil {
+			result = "Blob"
+		} else {
+			result += " | Blob"
+		}
+		request = append(request, "blob: true")
+	}
+
+	statuses := route.ErrorStatuses()
+	errorStatuses := make([]string, len(statuses))
+	for i, status := range statuses {
+		errorStatuses[i] = strconv.Itoa(status)
+	}
+	errorName := strings.ToUpper(route.Name[:1]) + route.Name[1:] + "Error"
+	request = append(request, "errors: "+route.Name+"Errors")
+
+	if callType == "" {
+		callType = result
+	}
+
+	return Endpoint{
+		Name:          route.Name,
+		Summary:       route.Summary,
+		Params:        params,
+		Result:        result,
+		ErrorName:     errorName,
+		ErrorStatuses: "[" + strings.Join(errorStatuses, ", ") + "]",
+		Request:       request,
+		Call:          call,
+		CallType:      callType,
+		Args:          args,
+	}, nil
+}
+
+// tsType names t as tygo does in protocol.ts, recording the protocol types the
+// client has to import.
+func (g *generator) tsType(t reflect.Type) (string, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return "string", nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number", nil
+	case reflect.String:
+		return "string", nil
+	case reflect.Slice, reflect.Array:
+		elem, err := g.tsType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return elem + "[]", nil
+	case reflect.Map:
+		value := "any"
+		if t.Elem().Kind() != reflect.Interface {
+			var err error
+			if value, err = g.tsType(t.Elem()); err != nil {
+				return "", err
+			}
+		}
+		return "{ [key: string]: " + value + " }", nil
+	case reflect.Struct:
+		if current, ok := versionedTypes[t]; ok {
+			if !sameJSON(t, current) {
+				return "", fmt.Errorf("%s no longer encodes like %s, so protocol.ts doesn't declare it", t, current)
+			}
+			t = current
+		}
+		if t.PkgPath() != protocolPkg {
+			return "", fmt.Errorf("%s isn't a protocol type, so protocol.ts doesn't declare it", t)
+		}
+		g.imports[t.Name()] = true
+		return t.Name(), nil
+	}
+	return "", fmt.Errorf("no TypeScript type for %s", t)
+}
+
+// sameJSON reports whether values of a and b encode with the same fields, of
+// the same types, in the same order.
+func sameJSON(a reflect.Type, b reflect.Type) bool {
+	if a == b {
+		return true
+	}
+	if a.Kind() != b.Kind() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return sameJSON(a.Elem(), b.Elem())
+	case reflect.Map:
+		return sameJSON(a.Key(), b.Key()) && sameJSON(a.Elem(), b.Elem())
+	case reflect.Struct:
+		if a.Name() != b.Name() || a.NumField() != b.NumField() {
+			return false
+		}
+		for i := range a.NumField() {
+			fa, fb := a.Field(i), b.Field(i)
+			if fa.Name != fb.Name || fa.Tag.Get("json") != fb.Tag.Get("json") || !sameJSON(fa.Type, fb.Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+// hasRequiredField reports whether a query struct has a field tygo doesn't
+// mark optional.
+func hasRequiredField(t reflect.Type) bool {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name != "-" && !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestClientUpToDate fails when a route or protocol type changed without the
+// client being regenerated with `just generate-client`.
+func TestClientUpToDate(t *testing.T) {
+	committed, err := os.ReadFile("../../../frontend/src/api/client.ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var generated bytes.Buffer
+	if err := generate(&generated); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(committed, generated.Bytes()) {
+		t.Error("frontend/src/api/client.ts is out of date; run `just generate-client`")
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"reminder-app/lib/webpush"
+)
+
+// Prints a new VAPID key pair for the backend's .env. Only the private key is
+// configured; the public key is derived from it and served to browsers.
+func main() {
+	publicKey, privateKey, err := webpush.GenerateVAPIDKey()
+	if err != nil {
+		log.Fatalf("Failed to generate VAPID key: %v", err)
+	}
+	fmt.Printf("# Public key: %s\n", publicKey)
+	fmt.Printf("PUSH_VAPID_PRIVATE_KEY=%s\n", privateKey)
+	fmt.Println("PUSH_VAPID_SUBJECT=mailto:you@example.com")
+}
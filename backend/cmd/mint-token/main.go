@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"reminder-app/config"
+	gormmodule "reminder-app/db/gorm"
+	localauth "reminder-app/lib/auth/local"
+	"reminder-app/models"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+func main() {
+	subject := flag.String("sub", "", "clerk_id of the user to mint a token for")
+	ttl := flag.Duration("ttl", 24*time.Hour, "how long the token is valid for")
+	createUser := flag.Bool("create-user", false, "create the user (and an email contact method) if it does not exist")
+	name := flag.String("name", "", "name for a user created with -create-user")
+	email := flag.String("email", "", "email contact method for a user created with -create-user")
+	flag.Parse()
+
+	if *subject == "" {
+		printUsage()
+		os.Exit(1)
+	}
+
+	// Create fx app for dependency injection
+	app := fx.New(
+		config.Module,
+		gormmodule.Module,
+		fx.Invoke(func(cfg *config.Config, gormDB *gorm.DB, lc fx.Lifecycle) {
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					provider, err := localauth.New(gormDB, cfg.Auth.LocalJWTSecret, cfg.Auth.LocalJWTIssuer)
+					if err != nil {
+						return err
+					}
+
+					if *createUser {
+						if err := ensureUser(gormDB, *subject, *name, *email); err != nil {
+							return err
+						}
+					}
+
+					token, err := provider.Mint(*subject, *ttl)
+					if err != nil {
+						return fmt.Errorf("failed to mint token: %w", err)
+					}
+
+					fmt.Println(token)
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					// Close database connections
+					if sqlDB, err := gormDB.DB(); err == nil {
+						sqlDB.Close()
+					}
+					return nil
+				},
+			})
+		}),
+		fx.NopLogger, // Suppress fx logs for cleaner output
+	)
+
+	// Start and stop the app immediately
+	if err := app.Start(context.Background()); err != nil {
+		log.Fatal("Failed to mint token: ", err)
+	}
+	if err := app.Stop(context.Background()); err != nil {
+		log.Fatal("Failed to stop app: ", err)
+	}
+}
+
+func ensureUser(db *gorm.DB, subject string, name string, email string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		err := tx.First(&user, "clerk_id = ?", subject).Error
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		user.ClerkID = subject
+		user.Name = name
+		if err := tx.Create(&user).Error; err != nil {
+			return fmt.Errorf("error creating user: %w", err)
+		}
+
+		if email != "" {
+			contactMethod := models.ContactMethod{
+				UserID:      int64(user.ID),
+				Type:        "email",
+				Value:       email,
+				Description: "Account email",
+			}
+			if err := tx.Create(&contactMethod).Error; err != nil {
+				return fmt.Errorf("error creating contact method: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func printUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  go run ./cmd/mint-token -sub <clerk_id> [-ttl 24h] [-create-user -name <name> -email <email>]")
+	fmt.Println("")
+	fmt.Println("Requires AUTH_LOCAL_JWT_SECRET. The backend must run with AUTH_PROVIDER=local to accept the token.")
+	fmt.Println("")
+	fmt.Println("Examples:")
+	fmt.Println("  go run ./cmd/mint-token -sub user_local_dev -create-user -name Dev -email dev@example.com")
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"reminder-app/config"
+	"reminder-app/lib/channel/telegram"
+)
+
+// Points the bot's webhook at this server's /webhooks/telegram, on
+// APP_API_URL, which Telegram must be able to reach over https.
+func main() {
+	cfg := config.New()
+	if cfg.Telegram.BotToken == "" || cfg.Telegram.WebhookSecret == "" {
+		log.Fatal("TELEGRAM_BOT_TOKEN and TELEGRAM_WEBHOOK_SECRET must be set")
+	}
+
+	bot := telegram.New(&http.Client{Timeout: 30 * time.Second}, cfg.Telegram.BotToken, cfg.Telegram.BotUsername)
+	webhookURL := strings.TrimSuffix(cfg.App.APIURL, "/") + "/webhooks/telegram"
+	if err := bot.SetWebhook(context.Background(), webhookURL, cfg.Telegram.WebhookSecret); err != nil {
+		log.Fatalf("Failed to set the Telegram webhook: %v", err)
+	}
+	log.Printf("Telegram will post updates to %s", webhookURL)
+}
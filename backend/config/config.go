@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/sethvargo/go-envconfig"
 )
@@ -10,6 +11,10 @@ import (
 type ResendConfig struct {
 	ApiKey string `env:"RESEND_API_KEY"`
 	Domain string `env:"RESEND_DOMAIN"`
+	// InboundDomain receives replies to reminder emails and mail to users'
+	// inbox addresses. Receiving is disabled while it's unset.
+	InboundDomain           string `env:"RESEND_INBOUND_DOMAIN"`
+	InboundWebhookSecretKey string `env:"RESEND_INBOUND_WEBHOOK_SECRET_KEY"`
 }
 
 type ClerkConfig struct {
@@ -17,12 +22,76 @@ type ClerkConfig struct {
 	WebhookSecretKey string `env:"CLERK_WEBHOOK_SECRET_KEY"`
 }
 
+type AppConfig struct {
+	// BaseURL is where the frontend is served, used to build links in emails.
+	BaseURL string `env:"APP_BASE_URL,default=http://localhost:5173"`
+	// APIURL is where this server is reachable, used to build links that
+	// bypass the frontend such as calendar feeds.
+	APIURL string `env:"APP_API_URL,default=http://localhost:8080"`
+	// SigningSecret keys invitation links and email reply addresses. It must
+	// be at least 32 bytes; generate one with `openssl rand -hex 32`.
+	SigningSecret string `env:"APP_SIGNING_SECRET"`
+	// TrashRetention is how long deleted reminders can be restored before
+	// they're purged. Zero keeps them forever.
+	TrashRetention time.Duration `env:"APP_TRASH_RETENTION,default=720h"`
+}
+
+type AuthConfig struct {
+	// Provider selects how bearer tokens are verified: "clerk" or "local".
+	Provider       string `env:"AUTH_PROVIDER,default=clerk"`
+	LocalJWTSecret string `env:"AUTH_LOCAL_JWT_SECRET"`
+	LocalJWTIssuer string `env:"AUTH_LOCAL_JWT_ISSUER,default=uchi-local"`
+}
+
+// PushConfig holds the VAPID key Web Push messages are sent with. Generate
+// one with `just generate-vapid-key`. Changing it invalidates every existing
+// browser subscription. Push is disabled while it's unset.
+type PushConfig struct {
+	VAPIDPrivateKey string `env:"PUSH_VAPID_PRIVATE_KEY"`
+	// VAPIDSubject is a mailto: or https: URL push services can contact the
+	// operator at.
+	VAPIDSubject string `env:"PUSH_VAPID_SUBJECT"`
+}
+
+type NtfyConfig struct {
+	// ServerURL is where ntfy contact methods that give only a topic are
+	// published.
+	ServerURL string `env:"NTFY_SERVER_URL,default=https://ntfy.sh"`
+}
+
+// TelegramConfig holds the bot reminders are sent from, created with
+// @BotFather. Telegram is disabled while BotToken is unset.
+type TelegramConfig struct {
+	BotToken string `env:"TELEGRAM_BOT_TOKEN"`
+	// BotUsername is the bot's @username, which link codes are sent to.
+	BotUsername string `env:"TELEGRAM_BOT_USERNAME"`
+	// WebhookSecret is sent by Telegram with every update to prove it's
+	// Telegram. Register the webhook with `just set-telegram-webhook`.
+	WebhookSecret string `env:"TELEGRAM_WEBHOOK_SECRET"`
+}
+
+// TwilioConfig holds the account that texts are sent and received with.
+// Point FromNumber's incoming message webhook at {APIURL}/webhooks/twilio.
+// Texting is disabled while any of them is unset.
+type TwilioConfig struct {
+	AccountSID string `env:"TWILIO_ACCOUNT_SID"`
+	AuthToken  string `env:"TWILIO_AUTH_TOKEN"`
+	// FromNumber is the E.164 number reminders are texted from.
+	FromNumber string `env:"TWILIO_FROM_NUMBER"`
+}
+
 type Config struct {
 	Env         string `env:"ENV,required"`
 	DatabaseURL string `env:"DATABASE_URL"`
 	Port        string `env:"PORT,default=8080"`
+	App         AppConfig
 	Resend      ResendConfig
 	Clerk       ClerkConfig
+	Auth        AuthConfig
+	Push        PushConfig
+	Ntfy        NtfyConfig
+	Telegram    TelegramConfig
+	Twilio      TwilioConfig
 }
 
 func New() *Config {
@@ -0,0 +1,125 @@
+package accountcontroller
+
+import (
+	"context"
+	"errors"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/models"
+	"reminder-app/workers"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// Exports are cheap to retry but shouldn't keep a user waiting for days.
+const exportMaxAttempts = 3
+
+var ErrExportNotFound = errs.NotFound("export not found")
+
+type Controller struct {
+	db          *gorm.DB
+	riverClient *river.Client[pgx.Tx]
+}
+
+type Params struct {
+	fx.In
+
+	DB    *gorm.DB
+	River *river.Client[pgx.Tx]
+}
+
+func New(p Params) *Controller {
+	return &Controller{db: p.DB, riverClient: p.River}
+}
+
+// RequestExport queues an archive of everything held about the user. The user
+// is emailed when it's ready.
+func (ctrl *Controller) RequestExport(userID int64) (*protocol.DataExport, error) {
+	export := models.DataExport{
+		UserID: userID,
+		Status: models.DataExportStatusPending,
+	}
+	if err := ctrl.db.Create(&export).Error; err != nil {
+		return nil, err
+	}
+
+	_, err := ctrl.riverClient.Insert(context.Background(), workers.DataExportJobArgs{ExportID: int64(export.ID)}, &river.InsertOpts{
+		MaxAttempts: exportMaxAttempts,
+	})
+	if err != nil {
+		ctrl.db.Unscoped().Delete(&export)
+		return nil, err
+	}
+	return toProtocolExport(export), nil
+}
+
+func (ctrl *Controller) GetExports(userID int64) ([]protocol.DataExport, error) {
+	var dbExports []models.DataExport
+	err := ctrl.db.
+		Omit("archive").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&dbExports).Error
+	if err != nil {
+		return nil, err
+	}
+
+	exports := make([]protocol.DataExport, 0, len(dbExports))
+	for _, dbExport := range dbExports {
+		exports = append(exports, *toProtocolExport(dbExport))
+	}
+	return exports, nil
+}
+
+// GetExportArchive returns the zip archive of a ready, unexpired export.
+func (ctrl *Controller) GetExportArchive(userID int64, id int64) ([]byte, error) {
+	var export models.DataExport
+	err := ctrl.db.
+		Where("id = ? AND user_id = ?", id, userID).
+		Where("status = ? AND expires_at > ?", models.DataExportStatusReady, time.Now()).
+		First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, err
+	}
+	return export.Archive, nil
+}
+
+// DeleteAccount locks the user out right away and queues the permanent
+// deletion of their account, their data and their identity with the auth
+// provider.
+func (ctrl *Controller) DeleteAccount(userID int64) error {
+	return ctrl.db.Transaction(func(tx *gorm.DB) error {
+		// A soft-deleted user can no longer authenticate.
+		result := tx.Where("id = ?", userID).Delete(&models.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errs.NotFound("user not found")
+		}
+
+		_, err := ctrl.riverClient.Insert(context.Background(), workers.AccountDeletionJobArgs{UserID: userID}, nil)
+		return err
+	})
+}
+
+func toProtocolExport(export models.DataExport) *protocol.DataExport {
+	protocolExport := &protocol.DataExport{
+		ID:          int64(export.ID),
+		Status:      export.Status,
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}
+	if export.Error != "" {
+		protocolExport.Error = &export.Error
+	}
+	return protocolExport
+}
@@ -0,0 +1,93 @@
+package backupcontroller
+
+import (
+	"reminder-app/controller/contactmethodcontroller"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/controller/remindercontroller"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	backupVersion = 1
+
+	formatJSON = "json"
+	formatCSV  = "csv"
+
+	typeReminders      = "reminders"
+	typeContactMethods = "contact_methods"
+
+	conflictSkip      = "skip"
+	conflictOverwrite = "overwrite"
+
+	statusCreated = "created"
+	statusUpdated = "updated"
+	statusSkipped = "skipped"
+	statusError   = "error"
+)
+
+// Controller exports a user's contact methods and personal reminders and
+// imports them back. Household reminders belong to the household and are
+// left out. Imports go through the contact method and reminder controllers so
+// validation and job scheduling match the regular endpoints.
+type Controller struct {
+	db                      *gorm.DB
+	reminderController      *remindercontroller.Controller
+	contactMethodController *contactmethodcontroller.Controller
+}
+
+type Params struct {
+	fx.In
+
+	DB                      *gorm.DB
+	ReminderController      *remindercontroller.Controller
+	ContactMethodController *contactmethodcontroller.Controller
+}
+
+func New(p Params) *Controller {
+	return &Controller{
+		db:                      p.DB,
+		reminderController:      p.ReminderController,
+		contactMethodController: p.ContactMethodController,
+	}
+}
+
+// parseFormat validates format and type, returning the defaulted format.
+func parseFormat(format string, kind string) (string, error) {
+	switch format {
+	case "", formatJSON:
+		return formatJSON, nil
+	case formatCSV:
+		if kind != typeReminders && kind != typeContactMethods {
+			return "", errs.Invalid("type must be reminders or contact_methods for csv")
+		}
+		return formatCSV, nil
+	default:
+		return "", errs.Invalid("format must be json or csv")
+	}
+}
+
+// ValidateExportQuery checks an export's format and type before anything is
+// written, so that errors aren't sent labelled as the export.
+func ValidateExportQuery(query *protocol.ExportQuery) error {
+	_, err := parseFormat(query.Format, query.Type)
+	return err
+}
+
+// ContentType and Filename describe the export for the given query, which
+// must already be valid.
+func ContentType(query *protocol.ExportQuery) string {
+	if query.Format == formatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
+
+func Filename(query *protocol.ExportQuery) string {
+	if query.Format == formatCSV {
+		return "uchi-" + query.Type + ".csv"
+	}
+	return "uchi-backup.json"
+}
@@ -0,0 +1,177 @@
+package backupcontroller
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reminder-app/controller/protocol"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// List cells (rotation, tags, excluded_times) join their items with this.
+const listSeparator = ";"
+
+var contactMethodColumns = []string{"id", "type", "value", "description"}
+
+var reminderColumns = []string{
+	"id", "body", "start_time", "is_repeating", "period_minutes",
+	"contact_method_id", "contact_method_type", "contact_method_value",
+	"rotation", "tags", "excluded_times",
+}
+
+func contactMethodRecord(contactMethod protocol.BackupContactMethod) []string {
+	return []string{
+		strconv.FormatInt(contactMethod.ID, 10),
+		contactMethod.Type,
+		contactMethod.Value,
+		contactMethod.Description,
+	}
+}
+
+func reminderRecord(reminder protocol.BackupReminder) []string {
+	rotation := make([]string, len(reminder.Rotation))
+	for i, id := range reminder.Rotation {
+		rotation[i] = strconv.FormatInt(id, 10)
+	}
+	excludedTimes := make([]string, len(reminder.ExcludedTimes))
+	for i, t := range reminder.ExcludedTimes {
+		excludedTimes[i] = t.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		strconv.FormatInt(reminder.ID, 10),
+		reminder.Body,
+		reminder.StartTime.UTC().Format(time.RFC3339),
+		strconv.FormatBool(reminder.IsRepeating),
+		strconv.FormatInt(reminder.PeriodMinutes, 10),
+		strconv.FormatInt(reminder.ContactMethodID, 10),
+		reminder.ContactMethodType,
+		reminder.ContactMethodValue,
+		strings.Join(rotation, listSeparator),
+		strings.Join(reminder.Tags, listSeparator),
+		strings.Join(excludedTimes, listSeparator),
+	}
+}
+
+// csvRows reads a CSV file with a header row and returns each row keyed by
+// column name. Unknown columns are an error so typos don't silently drop data.
+func csvRows(r io.Reader, columns []string) ([]map[string]string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		if !slices.Contains(columns, header[i]) {
+			return nil, fmt.Errorf("unknown column %q", header[i])
+		}
+	}
+
+	var rows []map[string]string
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := map[string]string{}
+		for i, value := range record {
+			if i < len(header) {
+				row[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+func parseContactMethodRow(row map[string]string) (protocol.BackupContactMethod, error) {
+	contactMethod := protocol.BackupContactMethod{
+		Type:        row["type"],
+		Value:       row["value"],
+		Description: row["description"],
+	}
+	var err error
+	if contactMethod.ID, err = parseInt(row, "id"); err != nil {
+		return contactMethod, err
+	}
+	return contactMethod, nil
+}
+
+func parseReminderRow(row map[string]string) (protocol.BackupReminder, error) {
+	reminder := protocol.BackupReminder{
+		Body:               row["body"],
+		ContactMethodType:  row["contact_method_type"],
+		ContactMethodValue: row["contact_method_value"],
+		Tags:               splitList(row["tags"]),
+	}
+
+	var err error
+	if reminder.ID, err = parseInt(row, "id"); err != nil {
+		return reminder, err
+	}
+	if reminder.PeriodMinutes, err = parseInt(row, "period_minutes"); err != nil {
+		return reminder, err
+	}
+	if reminder.ContactMethodID, err = parseInt(row, "contact_method_id"); err != nil {
+		return reminder, err
+	}
+	if value := row["is_repeating"]; value != "" {
+		if reminder.IsRepeating, err = strconv.ParseBool(value); err != nil {
+			return reminder, fmt.Errorf("invalid is_repeating %q", value)
+		}
+	}
+	if value := row["start_time"]; value != "" {
+		if reminder.StartTime, err = time.Parse(time.RFC3339, value); err != nil {
+			return reminder, fmt.Errorf("invalid start_time %q, expected RFC 3339", value)
+		}
+	}
+	for _, value := range splitList(row["rotation"]) {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return reminder, fmt.Errorf("invalid rotation id %q", value)
+		}
+		reminder.Rotation = append(reminder.Rotation, id)
+	}
+	for _, value := range splitList(row["excluded_times"]) {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return reminder, fmt.Errorf("invalid excluded time %q, expected RFC 3339", value)
+		}
+		reminder.ExcludedTimes = append(reminder.ExcludedTimes, t)
+	}
+	return reminder, nil
+}
+
+func parseInt(row map[string]string, column string) (int64, error) {
+	value := row[column]
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", column, value)
+	}
+	return n, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, listSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,173 @@
+package backupcontroller
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reminder-app/controller/protocol"
+	"reminder-app/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const exportBatchSize = 200
+
+// Export streams the user's data to w. Nothing is written if the query is
+// invalid, so the caller can still respond with an error.
+func (ctrl *Controller) Export(userID int64, query *protocol.ExportQuery, w io.Writer) error {
+	format, err := parseFormat(query.Format, query.Type)
+	if err != nil {
+		return err
+	}
+
+	var dbContactMethods []models.ContactMethod
+	if err := ctrl.db.Where("user_id = ?", userID).Order("id").Find(&dbContactMethods).Error; err != nil {
+		return err
+	}
+	contactMethods := map[int64]models.ContactMethod{}
+	for _, dbContactMethod := range dbContactMethods {
+		contactMethods[int64(dbContactMethod.ID)] = dbContactMethod
+	}
+
+	bw := bufio.NewWriter(w)
+	if format == formatCSV {
+		err = ctrl.exportCSV(userID, query.Type, dbContactMethods, contactMethods, bw)
+	} else {
+		err = ctrl.exportJSON(userID, dbContactMethods, contactMethods, bw)
+	}
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// exportJSON writes a protocol.Backup one element at a time so large
+// accounts don't have to be held in memory.
+func (ctrl *Controller) exportJSON(userID int64, dbContactMethods []models.ContactMethod, contactMethods map[int64]models.ContactMethod, w io.Writer) error {
+	exportedAt, err := json.Marshal(time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, `{"version":%d,"exported_at":%s,"contact_methods":[`, backupVersion, exportedAt); err != nil {
+		return err
+	}
+
+	for i, dbContactMethod := range dbContactMethods {
+		if err := writeJSONElement(w, i, toBackupContactMethod(dbContactMethod)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(w, `],"reminders":[`); err != nil {
+		return err
+	}
+
+	i := 0
+	err = ctrl.eachReminder(userID, func(dbReminder models.Reminder) error {
+		err := writeJSONElement(w, i, toBackupReminder(dbReminder, contactMethods))
+		i++
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]}\n")
+	return err
+}
+
+func writeJSONElement(w io.Writer, i int, v any) error {
+	if i > 0 {
+		if _, err := io.WriteString(w, ","); err != nil {
+			return err
+		}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func (ctrl *Controller) exportCSV(userID int64, kind string, dbContactMethods []models.ContactMethod, contactMethods map[int64]models.ContactMethod, w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if kind == typeContactMethods {
+		if err := cw.Write(contactMethodColumns); err != nil {
+			return err
+		}
+		for _, dbContactMethod := range dbContactMethods {
+			if err := cw.Write(contactMethodRecord(toBackupContactMethod(dbContactMethod))); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := cw.Write(reminderColumns); err != nil {
+			return err
+		}
+		err := ctrl.eachReminder(userID, func(dbReminder models.Reminder) error {
+			return cw.Write(reminderRecord(toBackupReminder(dbReminder, contactMethods)))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// eachReminder visits the user's personal reminders in batches.
+func (ctrl *Controller) eachReminder(userID int64, fn func(models.Reminder) error) error {
+	var dbReminders []models.Reminder
+	return ctrl.db.
+		Where("user_id = ? AND household_id IS NULL", userID).
+		Preload("RotationSlots", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Order("id").
+		FindInBatches(&dbReminders, exportBatchSize, func(tx *gorm.DB, batch int) error {
+			for _, dbReminder := range dbReminders {
+				if err := fn(dbReminder); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+func toBackupContactMethod(dbContactMethod models.ContactMethod) protocol.BackupContactMethod {
+	return protocol.BackupContactMethod{
+		ID:          int64(dbContactMethod.ID),
+		Type:        dbContactMethod.Type,
+		Value:       dbContactMethod.Value,
+		Description: dbContactMethod.Description,
+	}
+}
+
+func toBackupReminder(dbReminder models.Reminder, contactMethods map[int64]models.ContactMethod) protocol.BackupReminder {
+	reminder := protocol.BackupReminder{
+		ID:              int64(dbReminder.ID),
+		Body:            dbReminder.Body,
+		StartTime:       dbReminder.StartTime,
+		IsRepeating:     dbReminder.IsRepeating,
+		PeriodMinutes:   dbReminder.PeriodMinutes,
+		ContactMethodID: dbReminder.ContactMethodID,
+		Tags:            dbReminder.Tags,
+		ExcludedTimes:   dbReminder.ExcludedTimes,
+	}
+	// The address lets the reminder find its contact method again in another
+	// account, where the IDs differ.
+	if contactMethod, ok := contactMethods[dbReminder.ContactMethodID]; ok {
+		reminder.ContactMethodType = contactMethod.Type
+		reminder.ContactMethodValue = contactMethod.Value
+	}
+	for _, slot := range dbReminder.RotationSlots {
+		reminder.Rotation = append(reminder.Rotation, slot.ContactMethodID)
+	}
+	return reminder
+}
@@ -0,0 +1,323 @@
+package backupcontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reminder-app/controller/contactmethodcontroller"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"reminder-app/models"
+	"strings"
+)
+
+// Import reads a backup in the format written by Export and creates, updates
+// or skips each row. Rows fail independently; only an unreadable file fails
+// the whole import.
+//
+// A contact method conflicts with an existing one of the same type and value.
+// A reminder conflicts with an existing personal reminder with the same body
+// and start time.
+func (ctrl *Controller) Import(a *actor.Actor, r io.Reader, query *protocol.ImportQuery) (*protocol.ImportResult, error) {
+	format, err := parseFormat(query.Format, query.Type)
+	if err != nil {
+		return nil, err
+	}
+	onConflict := query.OnConflict
+	if onConflict == "" {
+		onConflict = conflictSkip
+	}
+	if onConflict != conflictSkip && onConflict != conflictOverwrite {
+		return nil, errs.Invalid("on_conflict must be skip or overwrite")
+	}
+
+	backup, rowErrs, err := readBackup(r, format, query.Type)
+	if err != nil {
+		return nil, errs.Invalid(fmt.Sprintf("invalid %s file: %v", format, err))
+	}
+
+	im, err := ctrl.newImporter(a, query.DryRun, onConflict)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &protocol.ImportResult{
+		DryRun:         query.DryRun,
+		ContactMethods: []protocol.ImportRowResult{},
+		Reminders:      []protocol.ImportRowResult{},
+	}
+	for i, contactMethod := range backup.ContactMethods {
+		row := protocol.ImportRowResult{Row: i + 1}
+		if err := rowErrs.contactMethods[i]; err != nil {
+			result.ContactMethods = append(result.ContactMethods, failed(row, err))
+			continue
+		}
+		result.ContactMethods = append(result.ContactMethods, im.importContactMethod(row, contactMethod))
+	}
+	for i, reminder := range backup.Reminders {
+		row := protocol.ImportRowResult{Row: i + 1}
+		if err := rowErrs.reminders[i]; err != nil {
+			result.Reminders = append(result.Reminders, failed(row, err))
+			continue
+		}
+		result.Reminders = append(result.Reminders, im.importReminder(row, reminder))
+	}
+	return result, nil
+}
+
+// rowErrors holds CSV rows that couldn't be parsed, by index.
+type rowErrors struct {
+	contactMethods map[int]error
+	reminders      map[int]error
+}
+
+func readBackup(r io.Reader, format string, kind string) (*protocol.Backup, rowErrors, error) {
+	rowErrs := rowErrors{contactMethods: map[int]error{}, reminders: map[int]error{}}
+
+	var backup protocol.Backup
+	if format == formatJSON {
+		if err := json.NewDecoder(r).Decode(&backup); err != nil {
+			return nil, rowErrs, err
+		}
+		if backup.Version > backupVersion {
+			return nil, rowErrs, fmt.Errorf("unsupported version %d", backup.Version)
+		}
+		return &backup, rowErrs, nil
+	}
+
+	if kind == typeContactMethods {
+		rows, err := csvRows(r, contactMethodColumns)
+		if err != nil {
+			return nil, rowErrs, err
+		}
+		for i, row := range rows {
+			contactMethod, err := parseContactMethodRow(row)
+			if err != nil {
+				rowErrs.contactMethods[i] = err
+			}
+			backup.ContactMethods = append(backup.ContactMethods, contactMethod)
+		}
+		return &backup, rowErrs, nil
+	}
+
+	rows, err := csvRows(r, reminderColumns)
+	if err != nil {
+		return nil, rowErrs, err
+	}
+	for i, row := range rows {
+		reminder, err := parseReminderRow(row)
+		if err != nil {
+			rowErrs.reminders[i] = err
+		}
+		backup.Reminders = append(backup.Reminders, reminder)
+	}
+	return &backup, rowErrs, nil
+}
+
+// importer carries the state of one import: the user's contact methods, and
+// how IDs in the file map onto them.
+type importer struct {
+	ctrl       *Controller
+	actor      *actor.Actor
+	dryRun     bool
+	onConflict string
+
+	// byAddress finds contact methods by type and value, including those
+	// created earlier in this import. In a dry run, new ones map to 0.
+	byAddress map[string]int64
+	// byFileID maps contact method IDs in the file to the user's IDs.
+	byFileID map[int64]int64
+	owned    map[int64]bool
+}
+
+func (ctrl *Controller) newImporter(a *actor.Actor, dryRun bool, onConflict string) (*importer, error) {
+	var dbContactMethods []models.ContactMethod
+	if err := ctrl.db.Where("user_id = ?", a.GetUserIDInt64()).Find(&dbContactMethods).Error; err != nil {
+		return nil, err
+	}
+
+	im := &importer{
+		ctrl:       ctrl,
+		actor:      a,
+		dryRun:     dryRun,
+		onConflict: onConflict,
+		byAddress:  map[string]int64{},
+		byFileID:   map[int64]int64{},
+		owned:      map[int64]bool{},
+	}
+	for _, dbContactMethod := range dbContactMethods {
+		im.byAddress[address(dbContactMethod.Type, dbContactMethod.Value)] = int64(dbContactMethod.ID)
+		im.owned[int64(dbContactMethod.ID)] = true
+	}
+	return im, nil
+}
+
+func address(contactType string, value string) string {
+	return contactType + ":" + strings.ToLower(strings.TrimSpace(value))
+}
+
+func (im *importer) importContactMethod(row protocol.ImportRowResult, contactMethod protocol.BackupContactMethod) protocol.ImportRowResult {
+	key := address(contactMethod.Type, contactMethod.Value)
+	existingID, exists := im.byAddress[key]
+
+	switch {
+	case exists && im.onConflict == conflictSkip:
+		row.Status = statusSkipped
+	case exists:
+		row.Status = statusUpdated
+		if !im.dryRun && existingID != 0 {
+			_, err := im.ctrl.contactMethodController.UpdateContactMethod(im.actor.GetUserIDInt64(), existingID, &protocol.UpdateContactMethodRequest{
+				Type:        contactMethod.Type,
+				Value:       contactMethod.Value,
+				Description: contactMethod.Description,
+			}, "")
+			if err != nil {
+				return failed(row, err)
+			}
+		}
+	default:
+		row.Status = statusCreated
+		req := &protocol.CreateContactMethodRequest{
+			Type:        contactMethod.Type,
+			Value:       contactMethod.Value,
+			Description: contactMethod.Description,
+		}
+		if im.dryRun {
+			if err := contactmethodcontroller.Validate(req.Type, req.Value); err != nil {
+				return failed(row, err)
+			}
+		} else {
+			created, err := im.ctrl.contactMethodController.CreateContactMethod(im.actor.GetUserIDInt64(), req)
+			if err != nil {
+				return failed(row, err)
+			}
+			existingID = created.ID
+		}
+		im.byAddress[key] = existingID
+	}
+
+	if contactMethod.ID != 0 {
+		im.byFileID[contactMethod.ID] = existingID
+	}
+	if existingID != 0 {
+		row.ID = &existingID
+	}
+	return row
+}
+
+// resolve maps a contact method reference from the file to one of the user's
+// contact methods.
+func (im *importer) resolve(id int64, contactType string, value string) (int64, error) {
+	if contactType != "" || value != "" {
+		if resolved, ok := im.byAddress[address(contactType, value)]; ok {
+			return resolved, nil
+		}
+		return 0, fmt.Errorf("contact method %s %s not found", contactType, value)
+	}
+	if resolved, ok := im.byFileID[id]; ok {
+		return resolved, nil
+	}
+	if im.owned[id] {
+		return id, nil
+	}
+	return 0, fmt.Errorf("contact method %d not found", id)
+}
+
+func (im *importer) importReminder(row protocol.ImportRowResult, reminder protocol.BackupReminder) protocol.ImportRowResult {
+	if strings.TrimSpace(reminder.Body) == "" {
+		return failed(row, errs.Invalid("body is required"))
+	}
+	if reminder.StartTime.IsZero() {
+		return failed(row, errs.Invalid("start_time is required"))
+	}
+	if reminder.IsRepeating && reminder.PeriodMinutes <= 0 {
+		return failed(row, errs.Invalid("period minutes must be greater than 0"))
+	}
+
+	contactMethodID, err := im.resolve(reminder.ContactMethodID, reminder.ContactMethodType, reminder.ContactMethodValue)
+	if err != nil && len(reminder.Rotation) == 0 {
+		return failed(row, err)
+	}
+	var rotation []int64
+	for _, id := range reminder.Rotation {
+		resolved, err := im.resolve(id, "", "")
+		if err != nil {
+			return failed(row, err)
+		}
+		rotation = append(rotation, resolved)
+	}
+
+	var existing models.Reminder
+	err = im.ctrl.db.
+		Where("user_id = ? AND household_id IS NULL", im.actor.GetUserIDInt64()).
+		Where("body = ? AND start_time = ?", reminder.Body, reminder.StartTime).
+		Limit(1).
+		Find(&existing).Error
+	if err != nil {
+		return failed(row, err)
+	}
+
+	if existing.ID != 0 {
+		id := int64(existing.ID)
+		row.ID = &id
+		if im.onConflict == conflictSkip {
+			row.Status = statusSkipped
+			return row
+		}
+		row.Status = statusUpdated
+		if im.dryRun {
+			return row
+		}
+		_, err := im.ctrl.reminderController.UpdateReminder(im.actor, id, &protocol.UpdateReminderRequest{
+			Body:            reminder.Body,
+			StartTime:       reminder.StartTime,
+			IsRepeating:     reminder.IsRepeating,
+			PeriodMinutes:   reminder.PeriodMinutes,
+			ContactMethodID: contactMethodID,
+			Rotation:        nonNil(rotation),
+			Tags:            nonNil(reminder.Tags),
+			ExcludedTimes:   nonNil(reminder.ExcludedTimes),
+		}, "")
+		if err != nil {
+			return failed(row, err)
+		}
+		return row
+	}
+
+	row.Status = statusCreated
+	if im.dryRun {
+		return row
+	}
+	created, err := im.ctrl.reminderController.CreateReminder(im.actor, &protocol.CreateReminderRequest{
+		Body:            reminder.Body,
+		StartTime:       reminder.StartTime,
+		IsRepeating:     reminder.IsRepeating,
+		PeriodMinutes:   reminder.PeriodMinutes,
+		ContactMethodID: contactMethodID,
+		Rotation:        rotation,
+		Tags:            reminder.Tags,
+		ExcludedTimes:   reminder.ExcludedTimes,
+	})
+	if err != nil {
+		return failed(row, err)
+	}
+	row.ID = &created.ID
+	return row
+}
+
+// nonNil turns a missing list into an empty one, so an overwrite clears the
+// field instead of leaving it unchanged.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func failed(row protocol.ImportRowResult, err error) protocol.ImportRowResult {
+	message := err.Error()
+	row.Status = statusError
+	row.Error = &message
+	return row
+}
@@ -0,0 +1,189 @@
+package calendarcontroller
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"reminder-app/config"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/controller/remindercontroller"
+	"reminder-app/lib/auth"
+	"reminder-app/lib/auth/pat"
+	"reminder-app/lib/ical"
+	"reminder-app/models"
+	"reminder-app/workers"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// TokenPrefix marks feed tokens so a leaked one is easy to recognize.
+const TokenPrefix = "uchi_cal_"
+
+// ErrFeedNotFound is returned for unknown or rotated feed tokens.
+var ErrFeedNotFound = errs.NotFound("calendar feed not found")
+
+type Controller struct {
+	db                 *gorm.DB
+	config             *config.Config
+	reminderController *remindercontroller.Controller
+}
+
+type Params struct {
+	fx.In
+
+	DB                 *gorm.DB
+	Config             *config.Config
+	ReminderController *remindercontroller.Controller
+}
+
+func New(p Params) *Controller {
+	return &Controller{db: p.DB, config: p.Config, reminderController: p.ReminderController}
+}
+
+func (ctrl *Controller) GetFeed(userID int64) (*protocol.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	if err := ctrl.db.Where("user_id = ?", userID).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFeedNotFound
+		}
+		return nil, err
+	}
+	return toProtocolFeed(feed), nil
+}
+
+// RotateFeed creates the user's feed, or replaces its token so that calendars
+// subscribed with the old URL stop receiving updates.
+func (ctrl *Controller) RotateFeed(userID int64) (*protocol.CalendarFeed, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	token := TokenPrefix + strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+
+	feed := models.CalendarFeed{
+		UserID:    userID,
+		Prefix:    token[:len(TokenPrefix)+4],
+		TokenHash: pat.Hash(token),
+	}
+	err := ctrl.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.CalendarFeed{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&feed).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	protocolFeed := toProtocolFeed(feed)
+	feedURL := strings.TrimSuffix(ctrl.config.App.APIURL, "/") + "/ical/" + token + ".ics"
+	protocolFeed.URL = &feedURL
+	return protocolFeed, nil
+}
+
+func (ctrl *Controller) DeleteFeed(userID int64) error {
+	result := ctrl.db.Unscoped().Where("user_id = ?", userID).Delete(&models.CalendarFeed{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrFeedNotFound
+	}
+	return nil
+}
+
+// RenderFeed writes every reminder visible to the feed's owner as iCalendar.
+func (ctrl *Controller) RenderFeed(token string, w io.Writer) error {
+	var feed models.CalendarFeed
+	if err := ctrl.db.Where("token_hash = ?", pat.Hash(token)).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrFeedNotFound
+		}
+		return err
+	}
+
+	var user models.User
+	if err := ctrl.db.First(&user, "id = ?", feed.UserID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrFeedNotFound
+		}
+		return err
+	}
+
+	a, err := auth.ActorForUser(ctrl.db, user)
+	if err != nil {
+		return err
+	}
+
+	dbReminders, err := ctrl.reminderController.ListReminders(a)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := ctrl.db.Model(&feed).UpdateColumn("last_fetched_at", now).Error; err != nil {
+		log.Printf("Failed to update last_fetched_at for calendar feed %d: %v", feed.ID, err)
+	}
+
+	host := "uchi"
+	if u, err := url.Parse(ctrl.config.App.BaseURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+
+	cal := ical.Calendar{Name: "Uchi reminders"}
+	for _, dbReminder := range dbReminders {
+		start, ok := feedStart(dbReminder)
+		if !ok {
+			continue
+		}
+		event := ical.Event{
+			UID:     fmt.Sprintf("reminder-%d@%s", dbReminder.ID, host),
+			Summary: dbReminder.Body,
+			URL:     ctrl.config.App.BaseURL,
+			Start:   start,
+			Stamp:   dbReminder.UpdatedAt,
+			Alarm:   true,
+		}
+		if dbReminder.IsRepeating && dbReminder.PeriodMinutes > 0 {
+			event.Every = time.Duration(dbReminder.PeriodMinutes) * time.Minute
+			event.Exclude = dbReminder.ExcludedTimes
+		}
+		cal.Events = append(cal.Events, event)
+	}
+	return ical.Write(w, cal)
+}
+
+// feedStart returns when a reminder's event starts in the feed, which is
+// where its occurrences resume after a pause. Calendars alert on their own,
+// so, like workers.Occurrences, a reminder paused indefinitely is left out,
+// as is a one-time reminder that falls within its pause.
+func feedStart(reminder models.Reminder) (time.Time, bool) {
+	if reminder.PausedAt == nil {
+		return reminder.StartTime, true
+	}
+	if reminder.PausedUntil == nil {
+		return time.Time{}, false
+	}
+	if !reminder.IsRepeating || reminder.PeriodMinutes <= 0 {
+		return reminder.StartTime, !reminder.StartTime.Before(*reminder.PausedUntil)
+	}
+	// Next is exclusive, so step back a nanosecond to include an occurrence
+	// at PausedUntil.
+	return workers.NewReminderSchedule(reminder).Next(reminder.PausedUntil.Add(-time.Nanosecond)), true
+}
+
+func toProtocolFeed(feed models.CalendarFeed) *protocol.CalendarFeed {
+	return &protocol.CalendarFeed{
+		Prefix:        feed.Prefix,
+		CreatedAt:     feed.CreatedAt,
+		LastFetchedAt: feed.LastFetchedAt,
+	}
+}
@@ -2,26 +2,31 @@ package clerkcontroller
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reminder-app/controller/accountcontroller"
 	"reminder-app/controller/protocol"
 	"reminder-app/models"
+	"strings"
 
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 )
 
 type Controller struct {
-	db *gorm.DB
+	db                *gorm.DB
+	accountController *accountcontroller.Controller
 }
 
 type Params struct {
 	fx.In
 
-	DB *gorm.DB
+	DB                *gorm.DB
+	AccountController *accountcontroller.Controller
 }
 
 func New(p Params) *Controller {
-	return &Controller{db: p.DB}
+	return &Controller{db: p.DB, accountController: p.AccountController}
 }
 
 func (ctrl *Controller) HandleClerkEvent(eventType string, payload []byte) error {
@@ -32,6 +37,12 @@ func (ctrl *Controller) HandleClerkEvent(eventType string, payload []byte) error
 			return fmt.Errorf("error unmarshalling clerk webhook: %w", err)
 		}
 		return ctrl.onUserCreated(event)
+	case "user.deleted":
+		var event protocol.ClerkUserDeletedEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("error unmarshalling clerk webhook: %w", err)
+		}
+		return ctrl.onUserDeleted(event)
 	default:
 		return fmt.Errorf("unexpected event type: %s", eventType)
 	}
@@ -42,6 +53,7 @@ func (ctrl *Controller) onUserCreated(event protocol.ClerkUserCreatedEvent) erro
 
 	return ctrl.db.Transaction(func(tx *gorm.DB) error {
 		user.ClerkID = event.Data.ID
+		user.Email = verifiedPrimaryEmail(event.Data)
 		if err := tx.Create(&user).Error; err != nil {
 			return fmt.Errorf("error creating user: %w", err)
 		}
@@ -56,6 +68,43 @@ func (ctrl *Controller) onUserCreated(event protocol.ClerkUserCreatedEvent) erro
 				return fmt.Errorf("error creating contact method: %w", err)
 			}
 		}
+
+		// Link invitations sent before this person signed up, but only to an
+		// address they've proven is theirs.
+		if user.Email != nil {
+			err := tx.Model(&models.Invitation{}).
+				Where("email = ? AND invitee_user_id IS NULL AND accepted_at IS NULL", *user.Email).
+				Update("invitee_user_id", user.ID).Error
+			if err != nil {
+				return fmt.Errorf("error linking invitations: %w", err)
+			}
+		}
 		return nil
 	})
 }
+
+// verifiedPrimaryEmail returns the user's primary email address, lowercased
+// like invitations', if Clerk has verified it.
+func verifiedPrimaryEmail(data protocol.ClerkUserData) *string {
+	for _, address := range data.EmailAddresses {
+		if address.ID == data.PrimaryEmailAddressID && address.Verification.Status == "verified" {
+			email := strings.ToLower(address.EmailAddress)
+			return &email
+		}
+	}
+	return nil
+}
+
+// onUserDeleted deletes the account of a user removed in Clerk. Deleting an
+// account here also deletes the Clerk user, so the user may already be gone.
+func (ctrl *Controller) onUserDeleted(event protocol.ClerkUserDeletedEvent) error {
+	var user models.User
+	err := ctrl.db.Where("clerk_id = ?", event.Data.ID).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting user: %w", err)
+	}
+	return ctrl.accountController.DeleteAccount(int64(user.ID))
+}
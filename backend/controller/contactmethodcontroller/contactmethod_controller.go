@@ -1,25 +1,47 @@
 package contactmethodcontroller
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reminder-app/controller/errs"
 	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"reminder-app/lib/channel/discord"
+	"reminder-app/lib/channel/gotify"
+	"reminder-app/lib/channel/ntfy"
+	"reminder-app/lib/channel/slack"
+	"reminder-app/lib/channel/twilio"
+	"reminder-app/lib/etag"
+	"reminder-app/lib/mergepatch"
+	"reminder-app/lib/webpush"
 	"reminder-app/models"
+	"slices"
+	"strings"
+	"time"
 
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 )
 
+var errContactMethodChanged = errs.PreconditionFailed("contact method has changed since it was read")
+
 type Controller struct {
-	db *gorm.DB
+	db   *gorm.DB
+	push *webpush.Sender
 }
 
 type Params struct {
 	fx.In
 
 	DB *gorm.DB
+	// Push is nil when push notifications aren't configured.
+	Push *webpush.Sender
 }
 
 func New(p Params) *Controller {
-	return &Controller{db: p.DB}
+	return &Controller{db: p.DB, push: p.Push}
 }
 
 func (ctrl *Controller) GetContactMethods(userID int64) ([]protocol.ContactMethod, error) {
@@ -31,45 +53,123 @@ func (ctrl *Controller) GetContactMethods(userID int64) ([]protocol.ContactMetho
 
 	var protocolContactMethods []protocol.ContactMethod
 	for _, dbContactMethod := range dbContactMethods {
-		protocolContactMethods = append(protocolContactMethods, protocol.ContactMethod{
-			ID:          int64(dbContactMethod.ID),
-			UserID:      dbContactMethod.UserID,
-			Type:        dbContactMethod.Type,
-			Value:       dbContactMethod.Value,
-			Description: dbContactMethod.Description,
-		})
+		protocolContactMethods = append(protocolContactMethods, toProtocolContactMethod(dbContactMethod))
 	}
 	return protocolContactMethods, nil
 }
 
 func (ctrl *Controller) CreateContactMethod(userID int64, contactMethod *protocol.CreateContactMethodRequest) (*protocol.ContactMethod, error) {
+	if err := Validate(contactMethod.Type, contactMethod.Value); err != nil {
+		return nil, err
+	}
+
 	dbContactMethod := &models.ContactMethod{
 		UserID:      userID,
 		Type:        contactMethod.Type,
 		Value:       contactMethod.Value,
 		Description: contactMethod.Description,
 	}
+	disabledAt, err := ctrl.optedOutAt(dbContactMethod.Type, dbContactMethod.Value)
+	if err != nil {
+		return nil, err
+	}
+	dbContactMethod.DisabledAt = disabledAt
 
-	err := ctrl.db.Create(dbContactMethod).Error
+	err = ctrl.db.Create(dbContactMethod).Error
 	if err != nil {
 		return nil, err
 	}
 
-	return &protocol.ContactMethod{
-		ID:          int64(dbContactMethod.ID),
-		UserID:      dbContactMethod.UserID,
+	created := toProtocolContactMethod(*dbContactMethod)
+	return &created, nil
+}
+
+// GetHouseholdContactMethods lists every member's contact methods so household
+// reminders can target any of them.
+func (ctrl *Controller) GetHouseholdContactMethods(a *actor.Actor, householdID int64) ([]protocol.ContactMethod, error) {
+	if !a.IsMember(householdID) {
+		return nil, errs.NotFound("household not found")
+	}
+
+	var dbContactMethods []models.ContactMethod
+	members := ctrl.db.Model(&models.HouseholdMember{}).Select("user_id").Where("household_id = ?", householdID)
+	err := ctrl.db.Where("user_id IN (?)", members).Order("user_id, id").Find(&dbContactMethods).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var protocolContactMethods []protocol.ContactMethod
+	for _, dbContactMethod := range dbContactMethods {
+		protocolContactMethods = append(protocolContactMethods, toProtocolContactMethod(dbContactMethod))
+	}
+	return protocolContactMethods, nil
+}
+
+func (ctrl *Controller) GetContactMethod(userID int64, id int64) (*protocol.ContactMethod, error) {
+	dbContactMethod, err := ctrl.find(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	contactMethod := toProtocolContactMethod(*dbContactMethod)
+	return &contactMethod, nil
+}
+
+// UpdateContactMethod replaces a contact method's fields. If ifMatch is set,
+// the update only succeeds if it matches the contact method's current ETag.
+func (ctrl *Controller) UpdateContactMethod(userID int64, id int64, contactMethod *protocol.UpdateContactMethodRequest, ifMatch string) (*protocol.ContactMethod, error) {
+	dbContactMethod, err := ctrl.find(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return ctrl.update(dbContactMethod, contactMethod, ifMatch)
+}
+
+// PatchContactMethod applies a JSON Merge Patch to the fields of
+// protocol.UpdateContactMethodRequest.
+func (ctrl *Controller) PatchContactMethod(userID int64, id int64, patch []byte, ifMatch string) (*protocol.ContactMethod, error) {
+	dbContactMethod, err := ctrl.find(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !etag.Matches(ifMatch, dbContactMethod.UpdatedAt) {
+		return nil, errContactMethodChanged
+	}
+
+	current, err := json.Marshal(protocol.UpdateContactMethodRequest{
 		Type:        dbContactMethod.Type,
 		Value:       dbContactMethod.Value,
 		Description: dbContactMethod.Description,
-	}, nil
-}
+	})
+	if err != nil {
+		return nil, err
+	}
+	merged, err := mergepatch.Apply(current, patch)
+	if err != nil {
+		return nil, errs.Invalid(fmt.Sprintf("invalid patch: %v", err))
+	}
 
-func (ctrl *Controller) UpdateContactMethod(id int64, contactMethod *protocol.UpdateContactMethodRequest) (*protocol.ContactMethod, error) {
-	// todo: validate that the contact method belongs to the user
+	var contactMethod protocol.UpdateContactMethodRequest
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&contactMethod); err != nil {
+		return nil, errs.Invalid(fmt.Sprintf("invalid patch: %v", err))
+	}
 
-	var dbContactMethod models.ContactMethod
-	if err := ctrl.db.Where("id = ?", id).First(&dbContactMethod).Error; err != nil {
-		return nil, err
+	return ctrl.update(dbContactMethod, &contactMethod, ifMatch)
+}
+
+func (ctrl *Controller) update(dbContactMethod *models.ContactMethod, contactMethod *protocol.UpdateContactMethodRequest, ifMatch string) (*protocol.ContactMethod, error) {
+	// The value was checked when it was saved, and some types can only be
+	// saved by their own flows.
+	if contactMethod.Type != dbContactMethod.Type || contactMethod.Value != dbContactMethod.Value {
+		if err := Validate(contactMethod.Type, contactMethod.Value); err != nil {
+			return nil, err
+		}
+		disabledAt, err := ctrl.optedOutAt(contactMethod.Type, contactMethod.Value)
+		if err != nil {
+			return nil, err
+		}
+		dbContactMethod.DisabledAt = disabledAt
 	}
 
 	// Update fields from request
@@ -77,23 +177,104 @@ func (ctrl *Controller) UpdateContactMethod(id int64, contactMethod *protocol.Up
 	dbContactMethod.Value = contactMethod.Value
 	dbContactMethod.Description = contactMethod.Description
 
-	err := ctrl.db.Save(&dbContactMethod).Error
-	if err != nil {
+	query := ctrl.db.Model(dbContactMethod)
+	if ifMatch != "" {
+		if !etag.Matches(ifMatch, dbContactMethod.UpdatedAt) {
+			return nil, errContactMethodChanged
+		}
+		// Only update the row if nobody else has since the ETag was checked.
+		query = query.Where("updated_at = ?", dbContactMethod.UpdatedAt)
+	}
+	result := query.Select("type", "value", "description", "disabled_at").Updates(dbContactMethod)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errContactMethodChanged
+	}
+
+	updated := toProtocolContactMethod(*dbContactMethod)
+	return &updated, nil
+}
+
+func (ctrl *Controller) DeleteContactMethod(userID int64, id int64) error {
+	result := ctrl.db.Where("user_id = ?", userID).Delete(&models.ContactMethod{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NotFound("contact method not found")
+	}
+	return nil
+}
+
+// optedOutAt returns when a phone number opted out of texts through any
+// contact method with it, including deleted ones, so that adding the number
+// again doesn't opt it back in.
+func (ctrl *Controller) optedOutAt(contactType string, value string) (*time.Time, error) {
+	if contactType != models.ContactTypePhone {
+		return nil, nil
+	}
+	var contactMethods []models.ContactMethod
+	err := ctrl.db.Unscoped().
+		Where("type = ? AND disabled_at IS NOT NULL AND regexp_replace(value, '[^0-9]', '', 'g') IN ?", models.ContactTypePhone, twilio.NumberVariants(value)).
+		Order("disabled_at").Limit(1).Find(&contactMethods).Error
+	if err != nil || len(contactMethods) == 0 {
+		return nil, err
+	}
+	return contactMethods[0].DisabledAt, nil
+}
+
+func (ctrl *Controller) find(userID int64, id int64) (*models.ContactMethod, error) {
+	var dbContactMethod models.ContactMethod
+	if err := ctrl.db.Where("user_id = ? AND id = ?", userID, id).First(&dbContactMethod).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NotFound("contact method not found")
+		}
 		return nil, err
 	}
+	return &dbContactMethod, nil
+}
 
-	return &protocol.ContactMethod{
+func toProtocolContactMethod(dbContactMethod models.ContactMethod) protocol.ContactMethod {
+	return protocol.ContactMethod{
 		ID:          int64(dbContactMethod.ID),
 		UserID:      dbContactMethod.UserID,
 		Type:        dbContactMethod.Type,
 		Value:       dbContactMethod.Value,
 		Description: dbContactMethod.Description,
-	}, nil
+		DisabledAt:  dbContactMethod.DisabledAt,
+		UpdatedAt:   dbContactMethod.UpdatedAt,
+	}
 }
 
-func (ctrl *Controller) DeleteContactMethod(id int64) error {
-	// todo: validate that the contact method belongs to the user
-
-	err := ctrl.db.Delete(&models.ContactMethod{}, id).Error
-	return err
+// Validate checks a contact method before it is saved.
+func Validate(contactType string, value string) error {
+	if !slices.Contains(models.ContactTypes, contactType) {
+		return errs.Invalid("unknown contact type: " + contactType)
+	}
+	if strings.TrimSpace(value) == "" {
+		return errs.Invalid("value is required")
+	}
+	var err error
+	switch contactType {
+	case models.ContactTypePush:
+		_, err = webpush.ParseSubscription(value)
+	case models.ContactTypeSlack:
+		err = slack.ValidateWebhookURL(value)
+	case models.ContactTypeDiscord:
+		err = discord.ValidateWebhookURL(value)
+	case models.ContactTypeNtfy:
+		err = ntfy.ValidateTarget(value)
+	case models.ContactTypeGotify:
+		err = gotify.ValidateTarget(value)
+	case models.ContactTypeTelegram:
+		// Chat IDs come from the bot, so nobody can send to a chat that
+		// didn't ask.
+		err = errors.New("telegram contact methods are added by choosing Connect Telegram and messaging the bot")
+	}
+	if err != nil {
+		return errs.Invalid(err.Error())
+	}
+	return nil
 }
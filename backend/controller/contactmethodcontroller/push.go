@@ -0,0 +1,72 @@
+package contactmethodcontroller
+
+import (
+	"encoding/json"
+	"errors"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/webpush"
+	"reminder-app/models"
+
+	"gorm.io/gorm"
+)
+
+var errPushDisabled = errs.Unavailable("push notifications aren't configured on this server")
+
+// GetPushPublicKey returns the key browsers subscribe to push with.
+func (ctrl *Controller) GetPushPublicKey() (*protocol.PushPublicKey, error) {
+	if ctrl.push == nil {
+		return nil, errPushDisabled
+	}
+	return &protocol.PushPublicKey{PublicKey: ctrl.push.PublicKey()}, nil
+}
+
+// CreatePushSubscription saves a browser's push subscription as a push
+// contact method. A browser that subscribes again, say after its keys
+// changed, updates the contact method it already has.
+func (ctrl *Controller) CreatePushSubscription(userID int64, req *protocol.CreatePushSubscriptionRequest) (*protocol.ContactMethod, error) {
+	if ctrl.push == nil {
+		return nil, errPushDisabled
+	}
+
+	var sub webpush.Subscription
+	sub.Endpoint = req.Endpoint
+	sub.Keys.P256dh = req.Keys.P256dh
+	sub.Keys.Auth = req.Keys.Auth
+	if err := sub.Validate(); err != nil {
+		return nil, errs.Invalid(err.Error())
+	}
+	value, err := json.Marshal(sub)
+	if err != nil {
+		return nil, err
+	}
+
+	// The CASE keeps Postgres from parsing other types' values as JSON.
+	var dbContactMethod models.ContactMethod
+	err = ctrl.db.
+		Where("user_id = ? AND type = ?", userID, models.ContactTypePush).
+		Where("CASE WHEN type = ? THEN value::jsonb->>'endpoint' END = ?", models.ContactTypePush, req.Endpoint).
+		First(&dbContactMethod).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		dbContactMethod = models.ContactMethod{
+			UserID:      userID,
+			Type:        models.ContactTypePush,
+			Value:       string(value),
+			Description: req.Description,
+		}
+		err = ctrl.db.Create(&dbContactMethod).Error
+	case err == nil:
+		dbContactMethod.Value = string(value)
+		if req.Description != "" {
+			dbContactMethod.Description = req.Description
+		}
+		err = ctrl.db.Model(&dbContactMethod).Select("value", "description").Updates(&dbContactMethod).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	created := toProtocolContactMethod(dbContactMethod)
+	return &created, nil
+}
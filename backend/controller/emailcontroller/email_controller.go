@@ -0,0 +1,180 @@
+package emailcontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/controller/remindercontroller"
+	"reminder-app/controller/replycontroller"
+	"reminder-app/lib/auth"
+	"reminder-app/lib/mail"
+	"reminder-app/lib/reply"
+	"reminder-app/models"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// defaultLeadTime is when a reminder emailed without saying when is due.
+const defaultLeadTime = time.Hour
+
+var errReceivingDisabled = errs.Unavailable("receiving email isn't configured on this server")
+
+// Controller handles mail received on the inbound domain: replies to
+// reminder emails and new reminders sent to users' inbox addresses.
+type Controller struct {
+	db                 *gorm.DB
+	addresses          *reply.Addresses
+	receiver           mail.Receiver
+	replies            *replycontroller.Controller
+	reminderController *remindercontroller.Controller
+}
+
+type Params struct {
+	fx.In
+
+	DB *gorm.DB
+	// Addresses is nil when inbound mail isn't configured.
+	Addresses          *reply.Addresses
+	Receiver           mail.Receiver
+	Replies            *replycontroller.Controller
+	ReminderController *remindercontroller.Controller
+}
+
+func New(p Params) *Controller {
+	return &Controller{
+		db:                 p.DB,
+		addresses:          p.Addresses,
+		receiver:           p.Receiver,
+		replies:            p.Replies,
+		reminderController: p.ReminderController,
+	}
+}
+
+func (ctrl *Controller) GetInbox(userID int64) (*protocol.EmailInbox, error) {
+	if ctrl.addresses == nil {
+		return nil, errReceivingDisabled
+	}
+	return &protocol.EmailInbox{Address: ctrl.addresses.ForUser(userID)}, nil
+}
+
+// HandleReceived fetches an email a webhook announced and applies it as a
+// reply or a new reminder, depending on the address it was sent to.
+func (ctrl *Controller) HandleReceived(ctx context.Context, emailID string) error {
+	if ctrl.addresses == nil {
+		return errReceivingDisabled
+	}
+	email, err := ctrl.receiver.GetReceivedEmail(ctx, emailID)
+	if err != nil {
+		return fmt.Errorf("failed to get received email: %w", err)
+	}
+
+	for _, to := range email.To {
+		kind, id, err := ctrl.addresses.Parse(to)
+		if err != nil {
+			continue
+		}
+		switch kind {
+		case reply.AddressDelivery:
+			return ctrl.applyReply(ctx, id, email)
+		case reply.AddressInbox:
+			return ctrl.createReminder(id, email)
+		}
+	}
+	return fmt.Errorf("email %s isn't to a reply or inbox address", emailID)
+}
+
+// applyReply applies the command at the top of a reply, above the quoted
+// reminder. The signed address is the credential, as it's only ever sent
+// to the delivery's contact method.
+func (ctrl *Controller) applyReply(ctx context.Context, deliveryID int64, email *mail.InboundEmail) error {
+	cmd, err := reply.Parse(firstLine(email.Text))
+	if err != nil {
+		return err
+	}
+
+	var delivery models.Delivery
+	if err := ctrl.db.First(&delivery, deliveryID).Error; err != nil {
+		return fmt.Errorf("failed to get delivery %d: %w", deliveryID, err)
+	}
+	// A deleted contact method no longer gets the reminder to reply to.
+	var contactMethod models.ContactMethod
+	if err := ctrl.db.First(&contactMethod, delivery.ContactMethodID).Error; err != nil {
+		return fmt.Errorf("failed to get contact method %d: %w", delivery.ContactMethodID, err)
+	}
+
+	_, err = ctrl.replies.Apply(ctx, delivery, cmd)
+	return err
+}
+
+// createReminder creates a one-time reminder from the email's subject, sent
+// to the user's email contact method it came from. Mail from addresses the
+// user hasn't added is ignored, since the inbox address never changes.
+func (ctrl *Controller) createReminder(userID int64, email *mail.InboundEmail) error {
+	from, err := netmail.ParseAddress(email.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender %q: %w", email.From, err)
+	}
+
+	var contactMethod models.ContactMethod
+	err = ctrl.db.Where("user_id = ? AND type = ? AND LOWER(value) = LOWER(?)", userID, models.ContactTypeEmail, from.Address).
+		First(&contactMethod).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%s isn't an email contact method of user %d", from.Address, userID)
+	}
+	if err != nil {
+		return err
+	}
+
+	var user models.User
+	if err := ctrl.db.First(&user, userID).Error; err != nil {
+		return err
+	}
+	a, err := auth.ActorForUser(ctrl.db, user)
+	if err != nil {
+		return err
+	}
+
+	text := strings.TrimSpace(email.Subject)
+	if text == "" {
+		text = firstLine(email.Text)
+	}
+	body, startTime := parseReminder(text, time.Now())
+	if body == "" {
+		return errors.New("email has no subject or text to make a reminder of")
+	}
+
+	_, err = ctrl.reminderController.CreateReminder(a, &protocol.CreateReminderRequest{
+		Body:            body,
+		StartTime:       startTime,
+		ContactMethodID: int64(contactMethod.ID),
+	})
+	return err
+}
+
+// parseReminder splits a trailing "in 2h" off text to say when the reminder
+// is due, or leaves it due after defaultLeadTime.
+func parseReminder(text string, now time.Time) (string, time.Time) {
+	if i := strings.LastIndex(strings.ToLower(text), " in "); i >= 0 {
+		if d, err := reply.ParseDuration(text[i+len(" in "):]); err == nil {
+			return strings.TrimSpace(text[:i]), now.Add(d)
+		}
+	}
+	return text, now.Add(defaultLeadTime)
+}
+
+// firstLine returns the first line of text that isn't blank or quoted.
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, ">") {
+			return line
+		}
+	}
+	return ""
+}
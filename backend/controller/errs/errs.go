@@ -0,0 +1,32 @@
+package errs
+
+import "errors"
+
+// Kinds of errors the handler maps to HTTP status codes. Controllers wrap them
+// with a user facing message via the constructors below.
+var (
+	ErrInvalid   = errors.New("invalid request")
+	ErrNotFound  = errors.New("not found")
+	ErrForbidden = errors.New("forbidden")
+	ErrConflict  = errors.New("conflict")
+	// ErrPreconditionFailed means the resource changed since the client read
+	// it, per its If-Match header.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrUnavailable means the server isn't configured for the request.
+	ErrUnavailable = errors.New("unavailable")
+)
+
+type Error struct {
+	kind error
+	msg  string
+}
+
+func (e *Error) Error() string { return e.msg }
+func (e *Error) Unwrap() error { return e.kind }
+
+func Invalid(msg string) error            { return &Error{kind: ErrInvalid, msg: msg} }
+func NotFound(msg string) error           { return &Error{kind: ErrNotFound, msg: msg} }
+func Forbidden(msg string) error          { return &Error{kind: ErrForbidden, msg: msg} }
+func Conflict(msg string) error           { return &Error{kind: ErrConflict, msg: msg} }
+func PreconditionFailed(msg string) error { return &Error{kind: ErrPreconditionFailed, msg: msg} }
+func Unavailable(msg string) error        { return &Error{kind: ErrUnavailable, msg: msg} }
@@ -0,0 +1,258 @@
+package householdcontroller
+
+import (
+	"errors"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"reminder-app/models"
+	"slices"
+	"strings"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+var roles = []string{models.HouseholdRoleOwner, models.HouseholdRoleAdmin, models.HouseholdRoleMember}
+
+type Controller struct {
+	db *gorm.DB
+}
+
+type Params struct {
+	fx.In
+
+	DB *gorm.DB
+}
+
+func New(p Params) *Controller {
+	return &Controller{db: p.DB}
+}
+
+func (ctrl *Controller) GetHouseholds(a *actor.Actor) ([]protocol.Household, error) {
+	var dbHouseholds []models.Household
+	err := ctrl.db.Where("id IN ?", a.HouseholdIDs()).Order("name").Find(&dbHouseholds).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var protocolHouseholds []protocol.Household
+	for _, dbHousehold := range dbHouseholds {
+		protocolHouseholds = append(protocolHouseholds, protocol.Household{
+			ID:   int64(dbHousehold.ID),
+			Name: dbHousehold.Name,
+			Role: a.Memberships[int64(dbHousehold.ID)],
+		})
+	}
+	return protocolHouseholds, nil
+}
+
+func (ctrl *Controller) CreateHousehold(a *actor.Actor, req *protocol.CreateHouseholdRequest) (*protocol.Household, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errs.Invalid("name is required")
+	}
+
+	household := &models.Household{Name: name}
+	err := ctrl.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(household).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.HouseholdMember{
+			HouseholdID: int64(household.ID),
+			UserID:      a.GetUserIDInt64(),
+			Role:        models.HouseholdRoleOwner,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &protocol.Household{
+		ID:   int64(household.ID),
+		Name: household.Name,
+		Role: models.HouseholdRoleOwner,
+	}, nil
+}
+
+func (ctrl *Controller) UpdateHousehold(a *actor.Actor, id int64, req *protocol.UpdateHouseholdRequest) (*protocol.Household, error) {
+	if !a.IsMember(id) {
+		return nil, errs.NotFound("household not found")
+	}
+	if !a.HasRole(id, models.HouseholdRoleOwner, models.HouseholdRoleAdmin) {
+		return nil, errs.Forbidden("only owners and admins can rename a household")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errs.Invalid("name is required")
+	}
+
+	var household models.Household
+	if err := ctrl.db.First(&household, id).Error; err != nil {
+		return nil, err
+	}
+	household.Name = name
+	if err := ctrl.db.Save(&household).Error; err != nil {
+		return nil, err
+	}
+
+	return &protocol.Household{
+		ID:   int64(household.ID),
+		Name: household.Name,
+		Role: a.Memberships[id],
+	}, nil
+}
+
+func (ctrl *Controller) DeleteHousehold(a *actor.Actor, id int64) error {
+	if !a.IsMember(id) {
+		return errs.NotFound("household not found")
+	}
+	if !a.HasRole(id, models.HouseholdRoleOwner) {
+		return errs.Forbidden("only owners can delete a household")
+	}
+
+	var reminderCount int64
+	if err := ctrl.db.Model(&models.Reminder{}).Where("household_id = ?", id).Count(&reminderCount).Error; err != nil {
+		return err
+	}
+	if reminderCount > 0 {
+		return errs.Conflict("delete or move the household's reminders first")
+	}
+
+	return ctrl.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("household_id = ?", id).Delete(&models.HouseholdMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Household{}, id).Error
+	})
+}
+
+func (ctrl *Controller) GetMembers(a *actor.Actor, householdID int64) ([]protocol.HouseholdMember, error) {
+	if !a.IsMember(householdID) {
+		return nil, errs.NotFound("household not found")
+	}
+
+	var members []protocol.HouseholdMember
+	err := ctrl.db.Model(&models.HouseholdMember{}).
+		Select("household_members.user_id, users.name, household_members.role").
+		Joins("JOIN users ON users.id = household_members.user_id").
+		Where("household_members.household_id = ?", householdID).
+		Order("household_members.created_at").
+		Scan(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// UpdateMemberRole lets owners promote or demote members, as long as the
+// household keeps at least one owner.
+func (ctrl *Controller) UpdateMemberRole(a *actor.Actor, householdID int64, userID int64, req *protocol.UpdateHouseholdMemberRequest) (*protocol.HouseholdMember, error) {
+	if !a.IsMember(householdID) {
+		return nil, errs.NotFound("household not found")
+	}
+	if !a.HasRole(householdID, models.HouseholdRoleOwner) {
+		return nil, errs.Forbidden("only owners can change roles")
+	}
+	if !slices.Contains(roles, req.Role) {
+		return nil, errs.Invalid("role must be one of owner, admin or member")
+	}
+
+	member, err := ctrl.findMember(householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Role == models.HouseholdRoleOwner && req.Role != models.HouseholdRoleOwner {
+		if err := ctrl.ensureAnotherOwner(householdID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	member.Role = req.Role
+	if err := ctrl.db.Save(member).Error; err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	if err := ctrl.db.First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+
+	return &protocol.HouseholdMember{
+		UserID: member.UserID,
+		Name:   user.Name,
+		Role:   member.Role,
+	}, nil
+}
+
+// RemoveMember removes a member from a household. Members may remove
+// themselves; owners and admins may remove anyone but an owner.
+func (ctrl *Controller) RemoveMember(a *actor.Actor, householdID int64, userID int64) error {
+	if !a.IsMember(householdID) {
+		return errs.NotFound("household not found")
+	}
+
+	member, err := ctrl.findMember(householdID, userID)
+	if err != nil {
+		return err
+	}
+
+	isSelf := userID == a.GetUserIDInt64()
+	if !isSelf {
+		if !a.HasRole(householdID, models.HouseholdRoleOwner, models.HouseholdRoleAdmin) {
+			return errs.Forbidden("only owners and admins can remove members")
+		}
+		if member.Role == models.HouseholdRoleOwner && !a.HasRole(householdID, models.HouseholdRoleOwner) {
+			return errs.Forbidden("only owners can remove an owner")
+		}
+	}
+
+	if member.Role == models.HouseholdRoleOwner {
+		if err := ctrl.ensureAnotherOwner(householdID, userID); err != nil {
+			return err
+		}
+	}
+
+	// Household reminders would otherwise keep notifying someone who left.
+	var targeted int64
+	err = ctrl.db.Model(&models.Reminder{}).
+		Joins("JOIN contact_methods ON contact_methods.id = reminders.contact_method_id").
+		Where("reminders.household_id = ? AND contact_methods.user_id = ?", householdID, userID).
+		Count(&targeted).Error
+	if err != nil {
+		return err
+	}
+	if targeted > 0 {
+		return errs.Conflict("reassign household reminders that notify this member first")
+	}
+
+	return ctrl.db.Unscoped().Delete(member).Error
+}
+
+func (ctrl *Controller) findMember(householdID int64, userID int64) (*models.HouseholdMember, error) {
+	var member models.HouseholdMember
+	err := ctrl.db.Where("household_id = ? AND user_id = ?", householdID, userID).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NotFound("member not found")
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (ctrl *Controller) ensureAnotherOwner(householdID int64, userID int64) error {
+	var owners int64
+	err := ctrl.db.Model(&models.HouseholdMember{}).
+		Where("household_id = ? AND role = ? AND user_id <> ?", householdID, models.HouseholdRoleOwner, userID).
+		Count(&owners).Error
+	if err != nil {
+		return err
+	}
+	if owners == 0 {
+		return errs.Conflict("a household needs at least one owner")
+	}
+	return nil
+}
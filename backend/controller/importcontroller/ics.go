@@ -0,0 +1,170 @@
+package importcontroller
+
+import (
+	"fmt"
+	"io"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"reminder-app/lib/ical"
+	"strings"
+	"time"
+)
+
+// ImportICS previews the reminders an iCalendar file maps to and, when
+// query.Confirm is set, creates the importable ones.
+func (ctrl *Controller) ImportICS(a *actor.Actor, r io.Reader, query *protocol.ImportICSQuery) (*protocol.ImportICSResponse, error) {
+	if query.Confirm && query.ContactMethodID == 0 {
+		return nil, errs.Invalid("contact_method_id is required to import")
+	}
+
+	cal, err := ical.Parse(r)
+	if err != nil {
+		return nil, errs.Invalid(fmt.Sprintf("invalid calendar: %v", err))
+	}
+	if cal.Name != "VCALENDAR" {
+		return nil, errs.Invalid("invalid calendar: expected VCALENDAR")
+	}
+
+	now := time.Now()
+	response := &protocol.ImportICSResponse{Reminders: []protocol.ImportedReminder{}}
+	for _, c := range cal.Children {
+		if c.Name != "VEVENT" && c.Name != "VTODO" {
+			continue
+		}
+
+		imported := toImportedReminder(c, now)
+		if query.Confirm && imported.Error == nil {
+			reminder, err := ctrl.reminderController.CreateReminder(a, &protocol.CreateReminderRequest{
+				HouseholdID:     query.HouseholdID,
+				Body:            imported.Body,
+				StartTime:       imported.StartTime,
+				IsRepeating:     imported.IsRepeating,
+				PeriodMinutes:   imported.PeriodMinutes,
+				ContactMethodID: query.ContactMethodID,
+				ExcludedTimes:   imported.ExcludedTimes,
+			})
+			if err != nil {
+				message := err.Error()
+				imported.Error = &message
+			} else {
+				imported.Reminder = reminder
+				response.Created++
+			}
+		}
+		response.Reminders = append(response.Reminders, imported)
+	}
+	return response, nil
+}
+
+// toImportedReminder maps a VEVENT or VTODO onto a reminder. Anything that
+// can't be represented faithfully is either a warning or, if the reminder
+// would fire at the wrong times, an error that skips the entry.
+func toImportedReminder(c *ical.Component, now time.Time) protocol.ImportedReminder {
+	imported := protocol.ImportedReminder{
+		UID:           c.Text("UID"),
+		Kind:          c.Name,
+		ExcludedTimes: []time.Time{},
+		Warnings:      []string{},
+	}
+	fail := func(format string, args ...any) protocol.ImportedReminder {
+		message := fmt.Sprintf(format, args...)
+		imported.Error = &message
+		return imported
+	}
+
+	imported.Body = strings.TrimSpace(c.Text("SUMMARY"))
+	if description := strings.TrimSpace(c.Text("DESCRIPTION")); description != "" {
+		if imported.Body == "" {
+			imported.Body = description
+		} else {
+			imported.Body += "\n\n" + description
+		}
+	}
+	if imported.Body == "" {
+		return fail("entry has no summary or description")
+	}
+
+	if c.Get("RECURRENCE-ID") != nil {
+		return fail("changes to a single occurrence of a recurring event are not imported")
+	}
+	if c.Name == "VTODO" && strings.EqualFold(c.Text("STATUS"), "COMPLETED") {
+		return fail("task is already completed")
+	}
+
+	// Tasks remind at their due time, events when they start.
+	startProperty := c.Get("DTSTART")
+	if due := c.Get("DUE"); c.Name == "VTODO" && due != nil {
+		startProperty = due
+	}
+	if startProperty == nil {
+		return fail("entry has no start time")
+	}
+	start, err := ical.ParseTime(*startProperty)
+	if err != nil {
+		return fail("%v", err)
+	}
+	imported.StartTime = start.UTC()
+	if start.AllDay {
+		imported.Warnings = append(imported.Warnings, "all-day entry reminds at midnight")
+	} else if start.Floating {
+		imported.Warnings = append(imported.Warnings, "no time zone given, times are read as UTC")
+	}
+
+	rrule := c.Get("RRULE")
+	if rrule == nil {
+		if imported.StartTime.Before(now) {
+			return fail("entry is in the past")
+		}
+		return imported
+	}
+
+	rule, err := ical.ParseRRule(rrule.Value)
+	if err != nil {
+		return fail("%v", err)
+	}
+	every, err := rule.Every(start.Time)
+	if err != nil {
+		return fail("unsupported recurrence: %v", err)
+	}
+	if every < time.Minute {
+		return fail("unsupported recurrence: repeats more than once a minute")
+	}
+	imported.IsRepeating = true
+	imported.PeriodMinutes = int64(every / time.Minute)
+	// Calendars keep a daily 9am at 9am local time across DST changes; a fixed
+	// period drifts by an hour instead.
+	if every >= 24*time.Hour && start.Location() != time.UTC {
+		imported.Warnings = append(imported.Warnings, "repeats at a fixed interval, so it shifts by an hour across daylight saving changes")
+	}
+
+	switch {
+	case rule.Count > 0:
+		last := imported.StartTime.Add(time.Duration(rule.Count-1) * every)
+		if last.Before(now) {
+			return fail("recurrence has already ended")
+		}
+		imported.Warnings = append(imported.Warnings, fmt.Sprintf("recurrence ends after %d occurrences, but the reminder repeats until it is deleted", rule.Count))
+	case !rule.Until.IsZero():
+		if rule.Until.Before(now) {
+			return fail("recurrence has already ended")
+		}
+		imported.Warnings = append(imported.Warnings, "recurrence has an end date, but the reminder repeats until it is deleted")
+	}
+
+	for _, exdate := range c.All("EXDATE") {
+		times, err := ical.ParseTimes(exdate)
+		if err != nil {
+			return fail("%v", err)
+		}
+		for _, t := range times {
+			// EXDATEs are in local time and may be an hour off the fixed period
+			// after a DST change, so snap each one to the nearest occurrence.
+			n := t.Sub(imported.StartTime).Round(every) / every
+			if n >= 0 {
+				imported.ExcludedTimes = append(imported.ExcludedTimes, imported.StartTime.Add(n*every))
+			}
+		}
+	}
+	return imported
+}
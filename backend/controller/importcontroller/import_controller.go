@@ -0,0 +1,23 @@
+package importcontroller
+
+import (
+	"reminder-app/controller/remindercontroller"
+
+	"go.uber.org/fx"
+)
+
+// Controller turns files from other tools into reminders. Reminders are
+// created through remindercontroller so their jobs are scheduled as usual.
+type Controller struct {
+	reminderController *remindercontroller.Controller
+}
+
+type Params struct {
+	fx.In
+
+	ReminderController *remindercontroller.Controller
+}
+
+func New(p Params) *Controller {
+	return &Controller{reminderController: p.ReminderController}
+}
@@ -0,0 +1,328 @@
+package invitationcontroller
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/mail"
+	"net/url"
+	"reminder-app/config"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	mailer "reminder-app/lib/mail"
+	"reminder-app/lib/signing"
+	"reminder-app/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	signingPurpose = "invitation"
+	invitationTTL  = 7 * 24 * time.Hour
+)
+
+type Controller struct {
+	db     *gorm.DB
+	config *config.Config
+	mail   mailer.Sender
+	signer *signing.Signer
+}
+
+type Params struct {
+	fx.In
+
+	DB     *gorm.DB
+	Config *config.Config
+	Mail   mailer.Sender
+	Signer *signing.Signer
+}
+
+func New(p Params) *Controller {
+	return &Controller{db: p.DB, config: p.Config, mail: p.Mail, signer: p.Signer}
+}
+
+func (ctrl *Controller) InviteToHousehold(a *actor.Actor, householdID int64, req *protocol.CreateHouseholdInvitationRequest) (*protocol.Invitation, error) {
+	if !a.IsMember(householdID) {
+		return nil, errs.NotFound("household not found")
+	}
+	if !a.HasRole(householdID, models.HouseholdRoleOwner, models.HouseholdRoleAdmin) {
+		return nil, errs.Forbidden("only owners and admins can invite members")
+	}
+
+	role := req.Role
+	if role == "" {
+		role = models.HouseholdRoleMember
+	}
+	if role != models.HouseholdRoleAdmin && role != models.HouseholdRoleMember {
+		return nil, errs.Invalid("role must be admin or member")
+	}
+
+	var household models.Household
+	if err := ctrl.db.First(&household, householdID).Error; err != nil {
+		return nil, err
+	}
+
+	invitation := &models.Invitation{
+		Kind:        models.InvitationKindHousehold,
+		HouseholdID: &householdID,
+		Role:        role,
+	}
+	subject := "You're invited to join " + household.Name
+	message := fmt.Sprintf("You've been invited to join the household <b>%s</b> on Uchi.", html.EscapeString(household.Name))
+	if err := ctrl.invite(a, invitation, req.Email, subject, message); err != nil {
+		return nil, err
+	}
+
+	result := toProtocolInvitation(*invitation)
+	return &result, nil
+}
+
+func (ctrl *Controller) ShareReminder(a *actor.Actor, reminderID int64, req *protocol.ShareReminderRequest) (*protocol.Invitation, error) {
+	var reminder models.Reminder
+	err := ctrl.db.Where("id = ? AND user_id = ?", reminderID, a.GetUserIDInt64()).First(&reminder).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NotFound("reminder not found")
+		}
+		return nil, err
+	}
+
+	invitation := &models.Invitation{
+		Kind:       models.InvitationKindReminder,
+		ReminderID: &reminderID,
+	}
+	subject := "A reminder was shared with you"
+	message := fmt.Sprintf("Someone shared the reminder <b>%s</b> with you on Uchi.", html.EscapeString(reminder.Body))
+	if err := ctrl.invite(a, invitation, req.Email, subject, message); err != nil {
+		return nil, err
+	}
+
+	result := toProtocolInvitation(*invitation)
+	return &result, nil
+}
+
+// GetInvitations lists pending invitations addressed to the actor.
+func (ctrl *Controller) GetInvitations(a *actor.Actor) ([]protocol.Invitation, error) {
+	var dbInvitations []models.Invitation
+	err := ctrl.pendingFor(a).Order("created_at DESC").Find(&dbInvitations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var protocolInvitations []protocol.Invitation
+	for _, dbInvitation := range dbInvitations {
+		protocolInvitations = append(protocolInvitations, toProtocolInvitation(dbInvitation))
+	}
+	return protocolInvitations, nil
+}
+
+func (ctrl *Controller) GetHouseholdInvitations(a *actor.Actor, householdID int64) ([]protocol.Invitation, error) {
+	if !a.HasRole(householdID, models.HouseholdRoleOwner, models.HouseholdRoleAdmin) {
+		return nil, errs.NotFound("household not found")
+	}
+
+	var dbInvitations []models.Invitation
+	err := ctrl.db.
+		Where("household_id = ? AND accepted_at IS NULL AND expires_at > ?", householdID, time.Now()).
+		Order("created_at DESC").
+		Find(&dbInvitations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var protocolInvitations []protocol.Invitation
+	for _, dbInvitation := range dbInvitations {
+		protocolInvitations = append(protocolInvitations, toProtocolInvitation(dbInvitation))
+	}
+	return protocolInvitations, nil
+}
+
+// RevokeInvitation deletes a pending invitation. The inviter and, for
+// household invitations, the household's owners and admins may revoke it.
+func (ctrl *Controller) RevokeInvitation(a *actor.Actor, id int64) error {
+	var invitation models.Invitation
+	if err := ctrl.db.Where("id = ? AND accepted_at IS NULL", id).First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.NotFound("invitation not found")
+		}
+		return err
+	}
+
+	canRevoke := invitation.InvitedByUserID == a.GetUserIDInt64() ||
+		(invitation.HouseholdID != nil && a.HasRole(*invitation.HouseholdID, models.HouseholdRoleOwner, models.HouseholdRoleAdmin))
+	if !canRevoke {
+		return errs.NotFound("invitation not found")
+	}
+
+	return ctrl.db.Delete(&invitation).Error
+}
+
+// AcceptInvitation verifies an invitation link and applies it to the actor,
+// who must be the user the invitation was addressed to.
+func (ctrl *Controller) AcceptInvitation(a *actor.Actor, req *protocol.AcceptInvitationRequest) (*protocol.Invitation, error) {
+	subject, err := ctrl.signer.Verify(signingPurpose, req.Token)
+	if err != nil {
+		return nil, errs.Invalid(err.Error())
+	}
+	id, err := strconv.ParseInt(subject, 10, 64)
+	if err != nil {
+		return nil, errs.Invalid(signing.ErrInvalidToken.Error())
+	}
+
+	var invitation models.Invitation
+	// The signed token is the credential: whoever was sent it can accept.
+	if err := ctrl.pending().Where("id = ?", id).First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NotFound("invitation not found")
+		}
+		return nil, err
+	}
+
+	err = ctrl.db.Transaction(func(tx *gorm.DB) error {
+		switch invitation.Kind {
+		case models.InvitationKindHousehold:
+			if err := acceptHousehold(tx, a, invitation); err != nil {
+				return err
+			}
+		case models.InvitationKindReminder:
+			if err := acceptReminder(tx, a, invitation, req.ContactMethodID); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected invitation kind: %s", invitation.Kind)
+		}
+
+		now := time.Now()
+		userID := a.GetUserIDInt64()
+		invitation.AcceptedAt = &now
+		invitation.InviteeUserID = &userID
+		return tx.Save(&invitation).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toProtocolInvitation(invitation)
+	return &result, nil
+}
+
+// Unsubscribe stops delivering a shared reminder to the actor.
+func (ctrl *Controller) Unsubscribe(a *actor.Actor, reminderID int64) error {
+	result := ctrl.db.Unscoped().
+		Where("reminder_id = ? AND user_id = ?", reminderID, a.GetUserIDInt64()).
+		Delete(&models.ReminderSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NotFound("subscription not found")
+	}
+	return nil
+}
+
+func acceptHousehold(tx *gorm.DB, a *actor.Actor, invitation models.Invitation) error {
+	if a.IsMember(*invitation.HouseholdID) {
+		return errs.Conflict("already a member of this household")
+	}
+	return tx.Create(&models.HouseholdMember{
+		HouseholdID: *invitation.HouseholdID,
+		UserID:      a.GetUserIDInt64(),
+		Role:        invitation.Role,
+	}).Error
+}
+
+func acceptReminder(tx *gorm.DB, a *actor.Actor, invitation models.Invitation, contactMethodID int64) error {
+	var count int64
+	err := tx.Model(&models.ContactMethod{}).
+		Where("id = ? AND user_id = ?", contactMethodID, a.GetUserIDInt64()).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errs.Invalid("contact method not found")
+	}
+
+	var reminderCount int64
+	if err := tx.Model(&models.Reminder{}).Where("id = ?", *invitation.ReminderID).Count(&reminderCount).Error; err != nil {
+		return err
+	}
+	if reminderCount == 0 {
+		return errs.NotFound("the shared reminder no longer exists")
+	}
+
+	return tx.Create(&models.ReminderSubscription{
+		ReminderID:      *invitation.ReminderID,
+		UserID:          a.GetUserIDInt64(),
+		ContactMethodID: contactMethodID,
+	}).Error
+}
+
+func (ctrl *Controller) invite(a *actor.Actor, invitation *models.Invitation, email string, subject string, message string) error {
+	address, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return errs.Invalid("invalid email address")
+	}
+
+	invitation.Email = strings.ToLower(address.Address)
+	invitation.InvitedByUserID = a.GetUserIDInt64()
+	invitation.ExpiresAt = time.Now().Add(invitationTTL)
+
+	// Link to the user whose verified email this is right away; otherwise
+	// onUserCreated does it at sign up.
+	var invitee models.User
+	err = ctrl.db.Where("email = ?", invitation.Email).First(&invitee).Error
+	if err == nil {
+		inviteeUserID := int64(invitee.ID)
+		invitation.InviteeUserID = &inviteeUserID
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err := ctrl.db.Create(invitation).Error; err != nil {
+		return err
+	}
+
+	token := ctrl.signer.Sign(signingPurpose, strconv.FormatUint(uint64(invitation.ID), 10), invitation.ExpiresAt)
+	link := ctrl.config.App.BaseURL + "/invitations/accept?token=" + url.QueryEscape(token)
+	body := fmt.Sprintf(`
+	<html>
+		<body>
+			<p style="font-size: 16px;">%s</p>
+			<p><a href="%s">Accept invitation</a></p>
+			<p style="color: #666;">This link expires in 7 days.</p>
+		</body>
+	</html>
+	`, message, html.EscapeString(link))
+
+	return ctrl.mail.Send(invitation.Email, subject, body)
+}
+
+// pending scopes to unaccepted, unexpired invitations.
+func (ctrl *Controller) pending() *gorm.DB {
+	return ctrl.db.Where("accepted_at IS NULL AND expires_at > ?", time.Now())
+}
+
+// pendingFor scopes to pending invitations addressed to the actor. Only
+// invitations linked to the actor count: an email contact method proves
+// nothing, as anyone can add any address.
+func (ctrl *Controller) pendingFor(a *actor.Actor) *gorm.DB {
+	return ctrl.pending().Where("invitee_user_id = ?", a.GetUserIDInt64())
+}
+
+func toProtocolInvitation(dbInvitation models.Invitation) protocol.Invitation {
+	return protocol.Invitation{
+		ID:          int64(dbInvitation.ID),
+		Kind:        dbInvitation.Kind,
+		HouseholdID: dbInvitation.HouseholdID,
+		ReminderID:  dbInvitation.ReminderID,
+		Role:        dbInvitation.Role,
+		Email:       dbInvitation.Email,
+		ExpiresAt:   dbInvitation.ExpiresAt,
+	}
+}
@@ -1,9 +1,20 @@
 package controller
 
 import (
+	"reminder-app/controller/accountcontroller"
+	"reminder-app/controller/backupcontroller"
+	"reminder-app/controller/calendarcontroller"
 	"reminder-app/controller/clerkcontroller"
 	"reminder-app/controller/contactmethodcontroller"
+	"reminder-app/controller/emailcontroller"
+	"reminder-app/controller/householdcontroller"
+	"reminder-app/controller/importcontroller"
+	"reminder-app/controller/invitationcontroller"
 	"reminder-app/controller/remindercontroller"
+	"reminder-app/controller/replycontroller"
+	"reminder-app/controller/smscontroller"
+	"reminder-app/controller/telegramcontroller"
+	"reminder-app/controller/tokencontroller"
 
 	"go.uber.org/fx"
 )
@@ -13,5 +24,16 @@ var Module = fx.Module("controller",
 		remindercontroller.New,
 		contactmethodcontroller.New,
 		clerkcontroller.New,
+		tokencontroller.New,
+		householdcontroller.New,
+		invitationcontroller.New,
+		calendarcontroller.New,
+		importcontroller.New,
+		backupcontroller.New,
+		accountcontroller.New,
+		replycontroller.New,
+		telegramcontroller.New,
+		emailcontroller.New,
+		smscontroller.New,
 	),
 )
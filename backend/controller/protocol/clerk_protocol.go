@@ -14,6 +14,19 @@ type ClerkUserCreatedEvent struct {
 	Type            string          `json:"type"`
 }
 
+type ClerkUserDeletedEvent struct {
+	Data      ClerkDeletedObject `json:"data"`
+	Object    string             `json:"object"`
+	Timestamp int64              `json:"timestamp"`
+	Type      string             `json:"type"`
+}
+
+type ClerkDeletedObject struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+	Object  string `json:"object"`
+}
+
 type ClerkUserData struct {
 	Birthday              string                 `json:"birthday"`
 	CreatedAt             int64                  `json:"created_at"`
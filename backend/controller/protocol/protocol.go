@@ -3,6 +3,7 @@ package protocol
 import "time"
 
 type CreateReminderRequest struct {
+	HouseholdID     *int64    `json:"household_id"`
 	Body            string    `json:"body"`
 	StartTime       time.Time `json:"start_time"`
 	IsRepeating     bool      `json:"is_repeating"`
@@ -10,18 +11,79 @@ type CreateReminderRequest struct {
 	ContactMethodID int64     `json:"contact_method_id"`
 	PhoneNumber     *string   `json:"phone_number"`
 	Email           *string   `json:"email"`
+	// Rotation lists contact methods that take turns receiving a repeating
+	// reminder, in order. ContactMethodID is ignored when it is set.
+	Rotation []int64  `json:"rotation,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	// ExcludedTimes are occurrences of a repeating reminder to skip.
+	ExcludedTimes []time.Time `json:"excluded_times,omitempty"`
 }
 
 type Reminder struct {
-	ID              int64     `json:"id"`
-	UserID          int64     `json:"user_id"`
-	Body            string    `json:"body"`
-	StartTime       time.Time `json:"start_time"`
-	IsRepeating     bool      `json:"is_repeating"`
-	PeriodMinutes   int64     `json:"period_minutes"`
-	ContactMethodID int64     `json:"contact_method_id"`
-	PhoneNumber     *string   `json:"phone_number"`
-	Email           *string   `json:"email"`
+	ID              int64             `json:"id"`
+	UserID          int64             `json:"user_id"`
+	HouseholdID     *int64            `json:"household_id"`
+	Body            string            `json:"body"`
+	StartTime       time.Time         `json:"start_time"`
+	IsRepeating     bool              `json:"is_repeating"`
+	PeriodMinutes   int64             `json:"period_minutes"`
+	ContactMethodID int64             `json:"contact_method_id"`
+	PhoneNumber     *string           `json:"phone_number"`
+	Email           *string           `json:"email"`
+	Tags            []string          `json:"tags"`
+	ExcludedTimes   []time.Time       `json:"excluded_times"`
+	Rotation        []int64           `json:"rotation"`
+	CurrentAssignee *RotationAssignee `json:"current_assignee"`
+	PausedAt        *time.Time        `json:"paused_at"`
+	PausedUntil     *time.Time        `json:"paused_until"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	// IsSubscribed marks someone else's reminder that was shared with the actor.
+	IsSubscribed bool `json:"is_subscribed"`
+	// DeletedAt is set on reminders in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// PauseReminderRequest pauses a reminder until Until, or indefinitely if it's
+// omitted.
+type PauseReminderRequest struct {
+	Until *time.Time `json:"until"`
+}
+
+// BatchRemindersRequest applies several operations in one transaction. With
+// Atomic, any failed operation rolls back the whole batch; otherwise only the
+// failed operations are rolled back.
+type BatchRemindersRequest struct {
+	Atomic     bool                     `json:"atomic"`
+	Operations []BatchReminderOperation `json:"operations"`
+}
+
+// BatchReminderOperation is one create, update, patch, delete, pause or
+// resume. Every op but create needs ID, and IfMatch applies to update and
+// patch. The op's own request goes in the field of the same name.
+type BatchReminderOperation struct {
+	Op      string                 `json:"op"`
+	ID      int64                  `json:"id,omitempty"`
+	IfMatch string                 `json:"if_match,omitempty"`
+	Create  *CreateReminderRequest `json:"create,omitempty"`
+	Update  *UpdateReminderRequest `json:"update,omitempty"`
+	Patch   map[string]any         `json:"patch,omitempty"`
+	Pause   *PauseReminderRequest  `json:"pause,omitempty"`
+}
+
+// BatchReminderResult reports one operation, in request order. Status is ok,
+// error, or rolled_back for an operation that succeeded in a batch that
+// didn't commit.
+type BatchReminderResult struct {
+	Index    int       `json:"index"`
+	Status   string    `json:"status"`
+	ID       *int64    `json:"id"`
+	Reminder *Reminder `json:"reminder,omitempty"`
+	Error    *string   `json:"error"`
+}
+
+type BatchRemindersResponse struct {
+	Committed bool                  `json:"committed"`
+	Results   []BatchReminderResult `json:"results"`
 }
 
 type ContactMethod struct {
@@ -30,6 +92,10 @@ type ContactMethod struct {
 	Type        string `json:"type"`
 	Value       string `json:"value"`
 	Description string `json:"description"`
+	// DisabledAt is when the recipient opted out of messages, such as by
+	// texting STOP. It's cleared when they opt back in.
+	DisabledAt *time.Time `json:"disabled_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
 }
 
 type CreateContactMethodRequest struct {
@@ -44,7 +110,40 @@ type UpdateContactMethodRequest struct {
 	Description string `json:"description"`
 }
 
+// CreatePushSubscriptionRequest is a browser's PushSubscription, as its
+// toJSON serializes it, with a Description to tell devices apart.
+type CreatePushSubscriptionRequest struct {
+	Endpoint    string               `json:"endpoint"`
+	Keys        PushSubscriptionKeys `json:"keys"`
+	Description string               `json:"description"`
+}
+
+type PushSubscriptionKeys struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+// PushPublicKey is the VAPID public key browsers pass to
+// PushManager.subscribe as applicationServerKey.
+type PushPublicKey struct {
+	PublicKey string `json:"public_key"`
+}
+
+// TelegramLink opens a chat with the bot that links it as a contact method.
+// It works once, until ExpiresAt.
+type TelegramLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// EmailInbox is the address a user can email to create reminders: the
+// subject is the reminder, optionally ending in when, such as "in 2h".
+type EmailInbox struct {
+	Address string `json:"address"`
+}
+
 type UpdateReminderRequest struct {
+	HouseholdID     *int64    `json:"household_id"`
 	Body            string    `json:"body"`
 	StartTime       time.Time `json:"start_time"`
 	IsRepeating     bool      `json:"is_repeating"`
@@ -52,6 +151,14 @@ type UpdateReminderRequest struct {
 	ContactMethodID int64     `json:"contact_method_id"`
 	PhoneNumber     *string   `json:"phone_number"`
 	Email           *string   `json:"email"`
+	// Rotation lists contact methods that take turns receiving a repeating
+	// reminder, in order. ContactMethodID is ignored when it is set.
+	// Omitting Rotation, Tags, ExcludedTimes or HouseholdID leaves them
+	// unchanged; send an empty list to clear them.
+	Rotation []int64  `json:"rotation,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	// ExcludedTimes are occurrences of a repeating reminder to skip.
+	ExcludedTimes []time.Time `json:"excluded_times,omitempty"`
 }
 
 type DeleteResponse struct {
@@ -64,4 +171,254 @@ type ErrorResponse struct {
 
 type GetRemindersQuery struct {
 	IncludePast bool `json:"include_past" form:"include_past"`
+	// Cursor is the next_cursor of the previous page.
+	Cursor          string     `json:"cursor,omitempty" form:"cursor"`
+	Limit           int        `json:"limit,omitempty" form:"limit"`
+	Repeating       *bool      `json:"repeating,omitempty" form:"repeating"`
+	ContactMethodID int64      `json:"contact_method_id,omitempty" form:"contact_method_id"`
+	From            *time.Time `json:"from,omitempty" form:"from"`
+	To              *time.Time `json:"to,omitempty" form:"to"`
+	Tag             string     `json:"tag,omitempty" form:"tag"`
+	// Status is "upcoming" or "past" and takes precedence over IncludePast.
+	Status string `json:"status,omitempty" form:"status"`
+	// Sort is start_time, created_at or updated_at, prefixed with "-" for descending.
+	Sort string `json:"sort,omitempty" form:"sort"`
+	// Q is a full-text search over the reminder body.
+	Q string `json:"q,omitempty" form:"q"`
+}
+
+type ReminderPage struct {
+	Reminders  []Reminder `json:"reminders"`
+	NextCursor *string    `json:"next_cursor"`
+}
+
+type APIToken struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateAPITokenRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// CreateAPITokenResponse is the only time the plaintext token is returned.
+type CreateAPITokenResponse struct {
+	APIToken `tstype:",extends"`
+	Token    string `json:"token"`
+}
+
+type Household struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+type HouseholdMember struct {
+	UserID int64  `json:"user_id"`
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+}
+
+type CreateHouseholdRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdateHouseholdRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdateHouseholdMemberRequest struct {
+	Role string `json:"role"`
+}
+
+type RotationAssignee struct {
+	Position        int   `json:"position"`
+	ContactMethodID int64 `json:"contact_method_id"`
+	UserID          int64 `json:"user_id"`
+}
+
+type SwapRotationRequest struct {
+	PositionA int `json:"position_a"`
+	PositionB int `json:"position_b"`
+}
+
+type Invitation struct {
+	ID          int64     `json:"id"`
+	Kind        string    `json:"kind"`
+	HouseholdID *int64    `json:"household_id"`
+	ReminderID  *int64    `json:"reminder_id"`
+	Role        string    `json:"role"`
+	Email       string    `json:"email"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type CreateHouseholdInvitationRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type ShareReminderRequest struct {
+	Email string `json:"email"`
+}
+
+// AcceptInvitationRequest accepts the signed token from an invitation link.
+// Reminder shares also need the contact method to deliver the reminder to.
+type AcceptInvitationRequest struct {
+	Token           string `json:"token"`
+	ContactMethodID int64  `json:"contact_method_id"`
+}
+
+// OccurrencesQuery bounds the expansion of reminder schedules into concrete
+// times. From defaults to now and To to 30 days after From.
+type OccurrencesQuery struct {
+	From  *time.Time `json:"from,omitempty" form:"from"`
+	To    *time.Time `json:"to,omitempty" form:"to"`
+	Limit int        `json:"limit,omitempty" form:"limit"`
+}
+
+// Occurrence is a single upcoming delivery of a reminder. ContactMethodID is
+// the assignee at that point in a rotation.
+type Occurrence struct {
+	ReminderID      int64     `json:"reminder_id"`
+	Body            string    `json:"body"`
+	OccursAt        time.Time `json:"occurs_at"`
+	ContactMethodID int64     `json:"contact_method_id"`
+}
+
+// CalendarFeed describes the user's iCalendar subscription. URL embeds the
+// secret token, so it is only returned when the feed is created or rotated.
+type CalendarFeed struct {
+	Prefix        string     `json:"prefix"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastFetchedAt *time.Time `json:"last_fetched_at"`
+	URL           *string    `json:"url,omitempty"`
+}
+
+// ImportICSQuery configures an .ics import. Without Confirm the file is only
+// previewed; ContactMethodID is required to create the reminders.
+type ImportICSQuery struct {
+	ContactMethodID int64  `json:"contact_method_id,omitempty" form:"contact_method_id"`
+	HouseholdID     *int64 `json:"household_id,omitempty" form:"household_id"`
+	Confirm         bool   `json:"confirm,omitempty" form:"confirm"`
+}
+
+// ImportedReminder is one VEVENT or VTODO from an imported calendar and the
+// reminder it maps to.
+type ImportedReminder struct {
+	UID           string      `json:"uid"`
+	Kind          string      `json:"kind"`
+	Body          string      `json:"body"`
+	StartTime     time.Time   `json:"start_time"`
+	IsRepeating   bool        `json:"is_repeating"`
+	PeriodMinutes int64       `json:"period_minutes"`
+	ExcludedTimes []time.Time `json:"excluded_times"`
+	Warnings      []string    `json:"warnings"`
+	// Error explains why the entry is skipped.
+	Error *string `json:"error"`
+	// Reminder is set once the entry has been created.
+	Reminder *Reminder `json:"reminder"`
+}
+
+type ImportICSResponse struct {
+	Reminders []ImportedReminder `json:"reminders"`
+	Created   int                `json:"created"`
+}
+
+// ExportQuery selects the export format: json (default) holds contact
+// methods and reminders together, csv holds one Type per file.
+type ExportQuery struct {
+	Format string `json:"format,omitempty" form:"format"`
+	// Type is reminders or contact_methods and is required for csv.
+	Type string `json:"type,omitempty" form:"type"`
+}
+
+// ImportQuery mirrors ExportQuery for the uploaded file. OnConflict is skip
+// (default) or overwrite; DryRun reports what would happen without saving.
+type ImportQuery struct {
+	Format     string `json:"format,omitempty" form:"format"`
+	Type       string `json:"type,omitempty" form:"type"`
+	DryRun     bool   `json:"dry_run,omitempty" form:"dry_run"`
+	OnConflict string `json:"on_conflict,omitempty" form:"on_conflict"`
+}
+
+// Backup is the JSON export of a user's contact methods and personal
+// reminders, and the format accepted by the import endpoint.
+type Backup struct {
+	Version        int                   `json:"version"`
+	ExportedAt     time.Time             `json:"exported_at"`
+	ContactMethods []BackupContactMethod `json:"contact_methods"`
+	Reminders      []BackupReminder      `json:"reminders"`
+}
+
+type BackupContactMethod struct {
+	ID          int64  `json:"id"`
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+// BackupReminder points at its contact method by ContactMethodID, which is
+// either an ID from the same backup or one of the importing user's contact
+// methods, or by ContactMethodType and ContactMethodValue.
+type BackupReminder struct {
+	ID                 int64       `json:"id"`
+	Body               string      `json:"body"`
+	StartTime          time.Time   `json:"start_time"`
+	IsRepeating        bool        `json:"is_repeating"`
+	PeriodMinutes      int64       `json:"period_minutes"`
+	ContactMethodID    int64       `json:"contact_method_id,omitempty"`
+	ContactMethodType  string      `json:"contact_method_type,omitempty"`
+	ContactMethodValue string      `json:"contact_method_value,omitempty"`
+	Rotation           []int64     `json:"rotation,omitempty"`
+	Tags               []string    `json:"tags,omitempty"`
+	ExcludedTimes      []time.Time `json:"excluded_times,omitempty"`
+}
+
+// ImportRowResult reports what happened to one row. Row is 1-based within its
+// section of the file. Status is created, updated, skipped or error.
+type ImportRowResult struct {
+	Row    int     `json:"row"`
+	Status string  `json:"status"`
+	ID     *int64  `json:"id"`
+	Error  *string `json:"error"`
+}
+
+type ImportResult struct {
+	DryRun         bool              `json:"dry_run"`
+	ContactMethods []ImportRowResult `json:"contact_methods"`
+	Reminders      []ImportRowResult `json:"reminders"`
+}
+
+// DataExport is a "download my data" request. Status is pending, ready or
+// failed; a ready archive can be downloaded until ExpiresAt.
+type DataExport struct {
+	ID          int64      `json:"id"`
+	Status      string     `json:"status"`
+	Error       *string    `json:"error"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+const (
+	ReminderCreated   = "reminder.created"
+	ReminderUpdated   = "reminder.updated"
+	ReminderDeleted   = "reminder.deleted"
+	ReminderDelivered = "reminder.delivered"
+)
+
+// ReminderEvent is streamed from /events when a reminder the user can see
+// changes. It names the reminder rather than carrying it, so clients refetch
+// what they display.
+type ReminderEvent struct {
+	Type       string    `json:"type"`
+	ReminderID int64     `json:"reminder_id"`
+	At         time.Time `json:"at"`
 }
@@ -0,0 +1,12 @@
+package protocol
+
+// ResendEvent is a Resend webhook. email.received events announce mail to
+// the inbound domain by ID, and its content is fetched separately.
+type ResendEvent struct {
+	Type string          `json:"type"`
+	Data ResendEventData `json:"data"`
+}
+
+type ResendEventData struct {
+	EmailID string `json:"email_id"`
+}
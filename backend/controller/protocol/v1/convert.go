@@ -0,0 +1,182 @@
+package v1
+
+import "reminder-app/controller/protocol"
+
+// The conversions copy field by field, so that a field added to a protocol
+// type doesn't appear in /api/v1 until it's added here too. Nil lists stay
+// nil, which they're encoded as.
+
+func FromReminder(r protocol.Reminder) Reminder {
+	return Reminder{
+		ID:              r.ID,
+		UserID:          r.UserID,
+		HouseholdID:     r.HouseholdID,
+		Body:            r.Body,
+		StartTime:       r.StartTime,
+		IsRepeating:     r.IsRepeating,
+		PeriodMinutes:   r.PeriodMinutes,
+		ContactMethodID: r.ContactMethodID,
+		PhoneNumber:     r.PhoneNumber,
+		Email:           r.Email,
+		Tags:            r.Tags,
+		ExcludedTimes:   r.ExcludedTimes,
+		Rotation:        r.Rotation,
+		CurrentAssignee: r.CurrentAssignee,
+		PausedAt:        r.PausedAt,
+		PausedUntil:     r.PausedUntil,
+		UpdatedAt:       r.UpdatedAt,
+		IsSubscribed:    r.IsSubscribed,
+		DeletedAt:       r.DeletedAt,
+	}
+}
+
+func FromReminders(rs []protocol.Reminder) []Reminder {
+	if rs == nil {
+		return nil
+	}
+	reminders := make([]Reminder, len(rs))
+	for i, r := range rs {
+		reminders[i] = FromReminder(r)
+	}
+	return reminders
+}
+
+func fromReminderPtr(r *protocol.Reminder) *Reminder {
+	if r == nil {
+		return nil
+	}
+	reminder := FromReminder(*r)
+	return &reminder
+}
+
+func FromReminderPage(p protocol.ReminderPage) ReminderPage {
+	return ReminderPage{Reminders: FromReminders(p.Reminders), NextCursor: p.NextCursor}
+}
+
+func (r CreateReminderRequest) Protocol() protocol.CreateReminderRequest {
+	return protocol.CreateReminderRequest{
+		HouseholdID:     r.HouseholdID,
+		Body:            r.Body,
+		StartTime:       r.StartTime,
+		IsRepeating:     r.IsRepeating,
+		PeriodMinutes:   r.PeriodMinutes,
+		ContactMethodID: r.ContactMethodID,
+		PhoneNumber:     r.PhoneNumber,
+		Email:           r.Email,
+		Rotation:        r.Rotation,
+		Tags:            r.Tags,
+		ExcludedTimes:   r.ExcludedTimes,
+	}
+}
+
+func (r UpdateReminderRequest) Protocol() protocol.UpdateReminderRequest {
+	return protocol.UpdateReminderRequest{
+		HouseholdID:     r.HouseholdID,
+		Body:            r.Body,
+		StartTime:       r.StartTime,
+		IsRepeating:     r.IsRepeating,
+		PeriodMinutes:   r.PeriodMinutes,
+		ContactMethodID: r.ContactMethodID,
+		PhoneNumber:     r.PhoneNumber,
+		Email:           r.Email,
+		Rotation:        r.Rotation,
+		Tags:            r.Tags,
+		ExcludedTimes:   r.ExcludedTimes,
+	}
+}
+
+func (r BatchRemindersRequest) Protocol() protocol.BatchRemindersRequest {
+	req := protocol.BatchRemindersRequest{
+		Atomic:     r.Atomic,
+		Operations: make([]protocol.BatchReminderOperation, len(r.Operations)),
+	}
+	for i, op := range r.Operations {
+		operation := protocol.BatchReminderOperation{
+			Op:      op.Op,
+			ID:      op.ID,
+			IfMatch: op.IfMatch,
+			Patch:   op.Patch,
+			Pause:   op.Pause,
+		}
+		if op.Create != nil {
+			create := op.Create.Protocol()
+			operation.Create = &create
+		}
+		if op.Update != nil {
+			update := op.Update.Protocol()
+			operation.Update = &update
+		}
+		req.Operations[i] = operation
+	}
+	return req
+}
+
+func FromBatchRemindersResponse(r protocol.BatchRemindersResponse) BatchRemindersResponse {
+	response := BatchRemindersResponse{
+		Committed: r.Committed,
+		Results:   make([]BatchReminderResult, len(r.Results)),
+	}
+	for i, result := range r.Results {
+		response.Results[i] = BatchReminderResult{
+			Index:    result.Index,
+			Status:   result.Status,
+			ID:       result.ID,
+			Reminder: fromReminderPtr(result.Reminder),
+			Error:    result.Error,
+		}
+	}
+	return response
+}
+
+func FromImportICSResponse(r protocol.ImportICSResponse) ImportICSResponse {
+	response := ImportICSResponse{
+		Reminders: make([]ImportedReminder, len(r.Reminders)),
+		Created:   r.Created,
+	}
+	for i, imported := range r.Reminders {
+		response.Reminders[i] = ImportedReminder{
+			UID:           imported.UID,
+			Kind:          imported.Kind,
+			Body:          imported.Body,
+			StartTime:     imported.StartTime,
+			IsRepeating:   imported.IsRepeating,
+			PeriodMinutes: imported.PeriodMinutes,
+			ExcludedTimes: imported.ExcludedTimes,
+			Warnings:      imported.Warnings,
+			Error:         imported.Error,
+			Reminder:      fromReminderPtr(imported.Reminder),
+		}
+	}
+	return response
+}
+
+func FromContactMethod(c protocol.ContactMethod) ContactMethod {
+	return ContactMethod{
+		ID:          c.ID,
+		UserID:      c.UserID,
+		Type:        c.Type,
+		Value:       c.Value,
+		Description: c.Description,
+		DisabledAt:  c.DisabledAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+func FromContactMethods(cs []protocol.ContactMethod) []ContactMethod {
+	if cs == nil {
+		return nil
+	}
+	contactMethods := make([]ContactMethod, len(cs))
+	for i, c := range cs {
+		contactMethods[i] = FromContactMethod(c)
+	}
+	return contactMethods
+}
+
+func (r CreateContactMethodRequest) Protocol() protocol.CreateContactMethodRequest {
+	return protocol.CreateContactMethodRequest{Type: r.Type, Value: r.Value, Description: r.Description}
+}
+
+func (r UpdateContactMethodRequest) Protocol() protocol.UpdateContactMethodRequest {
+	return protocol.UpdateContactMethodRequest{Type: r.Type, Value: r.Value, Description: r.Description}
+}
@@ -0,0 +1,199 @@
+// Package v1 is the wire format of /api/v1. Reminders, contact methods and
+// the requests that create and change them are its own types, converted to
+// and from the protocol types in the handlers, so that changes to the
+// protocol don't change /api/v1. The rest are aliases of the current
+// protocol types; when one changes incompatibly, its alias here becomes a
+// copy of the old shape in the same way.
+package v1
+
+import (
+	"reminder-app/controller/protocol"
+	"time"
+)
+
+type (
+	PauseReminderRequest             = protocol.PauseReminderRequest
+	CreatePushSubscriptionRequest    = protocol.CreatePushSubscriptionRequest
+	PushSubscriptionKeys             = protocol.PushSubscriptionKeys
+	PushPublicKey                    = protocol.PushPublicKey
+	TelegramLink                     = protocol.TelegramLink
+	EmailInbox                       = protocol.EmailInbox
+	DeleteResponse                   = protocol.DeleteResponse
+	ErrorResponse                    = protocol.ErrorResponse
+	GetRemindersQuery                = protocol.GetRemindersQuery
+	APIToken                         = protocol.APIToken
+	CreateAPITokenRequest            = protocol.CreateAPITokenRequest
+	CreateAPITokenResponse           = protocol.CreateAPITokenResponse
+	Household                        = protocol.Household
+	HouseholdMember                  = protocol.HouseholdMember
+	CreateHouseholdRequest           = protocol.CreateHouseholdRequest
+	UpdateHouseholdRequest           = protocol.UpdateHouseholdRequest
+	UpdateHouseholdMemberRequest     = protocol.UpdateHouseholdMemberRequest
+	RotationAssignee                 = protocol.RotationAssignee
+	SwapRotationRequest              = protocol.SwapRotationRequest
+	Invitation                       = protocol.Invitation
+	CreateHouseholdInvitationRequest = protocol.CreateHouseholdInvitationRequest
+	ShareReminderRequest             = protocol.ShareReminderRequest
+	AcceptInvitationRequest          = protocol.AcceptInvitationRequest
+	OccurrencesQuery                 = protocol.OccurrencesQuery
+	Occurrence                       = protocol.Occurrence
+	CalendarFeed                     = protocol.CalendarFeed
+	ImportICSQuery                   = protocol.ImportICSQuery
+	ExportQuery                      = protocol.ExportQuery
+	ImportQuery                      = protocol.ImportQuery
+	Backup                           = protocol.Backup
+	BackupContactMethod              = protocol.BackupContactMethod
+	BackupReminder                   = protocol.BackupReminder
+	ImportRowResult                  = protocol.ImportRowResult
+	ImportResult                     = protocol.ImportResult
+	DataExport                       = protocol.DataExport
+	ReminderEvent                    = protocol.ReminderEvent
+)
+
+type CreateReminderRequest struct {
+	HouseholdID     *int64    `json:"household_id"`
+	Body            string    `json:"body"`
+	StartTime       time.Time `json:"start_time"`
+	IsRepeating     bool      `json:"is_repeating"`
+	PeriodMinutes   int64     `json:"period_minutes"`
+	ContactMethodID int64     `json:"contact_method_id"`
+	PhoneNumber     *string   `json:"phone_number"`
+	Email           *string   `json:"email"`
+	// Rotation lists contact methods that take turns receiving a repeating
+	// reminder, in order. ContactMethodID is ignored when it is set.
+	Rotation []int64  `json:"rotation,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	// ExcludedTimes are occurrences of a repeating reminder to skip.
+	ExcludedTimes []time.Time `json:"excluded_times,omitempty"`
+}
+
+type UpdateReminderRequest struct {
+	HouseholdID     *int64    `json:"household_id"`
+	Body            string    `json:"body"`
+	StartTime       time.Time `json:"start_time"`
+	IsRepeating     bool      `json:"is_repeating"`
+	PeriodMinutes   int64     `json:"period_minutes"`
+	ContactMethodID int64     `json:"contact_method_id"`
+	PhoneNumber     *string   `json:"phone_number"`
+	Email           *string   `json:"email"`
+	// Rotation lists contact methods that take turns receiving a repeating
+	// reminder, in order. ContactMethodID is ignored when it is set.
+	// Omitting Rotation, Tags, ExcludedTimes or HouseholdID leaves them
+	// unchanged; send an empty list to clear them.
+	Rotation []int64  `json:"rotation,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	// ExcludedTimes are occurrences of a repeating reminder to skip.
+	ExcludedTimes []time.Time `json:"excluded_times,omitempty"`
+}
+
+type Reminder struct {
+	ID              int64             `json:"id"`
+	UserID          int64             `json:"user_id"`
+	HouseholdID     *int64            `json:"household_id"`
+	Body            string            `json:"body"`
+	StartTime       time.Time         `json:"start_time"`
+	IsRepeating     bool              `json:"is_repeating"`
+	PeriodMinutes   int64             `json:"period_minutes"`
+	ContactMethodID int64             `json:"contact_method_id"`
+	PhoneNumber     *string           `json:"phone_number"`
+	Email           *string           `json:"email"`
+	Tags            []string          `json:"tags"`
+	ExcludedTimes   []time.Time       `json:"excluded_times"`
+	Rotation        []int64           `json:"rotation"`
+	CurrentAssignee *RotationAssignee `json:"current_assignee"`
+	PausedAt        *time.Time        `json:"paused_at"`
+	PausedUntil     *time.Time        `json:"paused_until"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	// IsSubscribed marks someone else's reminder that was shared with the actor.
+	IsSubscribed bool `json:"is_subscribed"`
+	// DeletedAt is set on reminders in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type ReminderPage struct {
+	Reminders  []Reminder `json:"reminders"`
+	NextCursor *string    `json:"next_cursor"`
+}
+
+// BatchRemindersRequest applies several operations in one transaction. With
+// Atomic, any failed operation rolls back the whole batch; otherwise only the
+// failed operations are rolled back.
+type BatchRemindersRequest struct {
+	Atomic     bool                     `json:"atomic"`
+	Operations []BatchReminderOperation `json:"operations"`
+}
+
+// BatchReminderOperation is one create, update, patch, delete, pause or
+// resume. Every op but create needs ID, and IfMatch applies to update and
+// patch. The op's own request goes in the field of the same name.
+type BatchReminderOperation struct {
+	Op      string                 `json:"op"`
+	ID      int64                  `json:"id,omitempty"`
+	IfMatch string                 `json:"if_match,omitempty"`
+	Create  *CreateReminderRequest `json:"create,omitempty"`
+	Update  *UpdateReminderRequest `json:"update,omitempty"`
+	Patch   map[string]any         `json:"patch,omitempty"`
+	Pause   *PauseReminderRequest  `json:"pause,omitempty"`
+}
+
+// BatchReminderResult reports one operation, in request order. Status is ok,
+// error, or rolled_back for an operation that succeeded in a batch that
+// didn't commit.
+type BatchReminderResult struct {
+	Index    int       `json:"index"`
+	Status   string    `json:"status"`
+	ID       *int64    `json:"id"`
+	Reminder *Reminder `json:"reminder,omitempty"`
+	Error    *string   `json:"error"`
+}
+
+type BatchRemindersResponse struct {
+	Committed bool                  `json:"committed"`
+	Results   []BatchReminderResult `json:"results"`
+}
+
+type ContactMethod struct {
+	ID          int64  `json:"id"`
+	UserID      int64  `json:"user_id"`
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+	// DisabledAt is when the recipient opted out of messages, such as by
+	// texting STOP. It's cleared when they opt back in.
+	DisabledAt *time.Time `json:"disabled_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type CreateContactMethodRequest struct {
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+type UpdateContactMethodRequest struct {
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+// ImportedReminder is one VEVENT or VTODO from an imported calendar and the
+// reminder it maps to.
+type ImportedReminder struct {
+	UID           string      `json:"uid"`
+	Kind          string      `json:"kind"`
+	Body          string      `json:"body"`
+	StartTime     time.Time   `json:"start_time"`
+	IsRepeating   bool        `json:"is_repeating"`
+	PeriodMinutes int64       `json:"period_minutes"`
+	ExcludedTimes []time.Time `json:"excluded_times"`
+	Warnings      []string    `json:"warnings"`
+	// Error explains why the entry is skipped.
+	Error *string `json:"error"`
+	// Reminder is set once the entry has been created.
+	Reminder *Reminder `json:"reminder"`
+}
+
+type ImportICSResponse struct {
+	Reminders []ImportedReminder `json:"reminders"`
+	Created   int                `json:"created"`
+}
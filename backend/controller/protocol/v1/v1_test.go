@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"encoding/json"
+	"reflect"
+	"reminder-app/controller/protocol"
+	"testing"
+	"time"
+)
+
+// These pin the JSON /api/v1 clients depend on. If one fails, a change to
+// the protocol has leaked into v1: keep v1's shape and convert instead.
+
+func TestReminderJSON(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 9, 30, 0, 0, time.UTC)
+	householdID := int64(3)
+	phone := "5551234567"
+	email := "me@example.com"
+	reminder := FromReminder(protocol.Reminder{
+		ID:              1,
+		UserID:          2,
+		HouseholdID:     &householdID,
+		Body:            "Water the plants",
+		StartTime:       at,
+		IsRepeating:     true,
+		PeriodMinutes:   1440,
+		ContactMethodID: 4,
+		PhoneNumber:     &phone,
+		Email:           &email,
+		Tags:            []string{"garden"},
+		ExcludedTimes:   []time.Time{at.Add(24 * time.Hour)},
+		Rotation:        []int64{4, 5},
+		CurrentAssignee: &protocol.RotationAssignee{Position: 0, ContactMethodID: 4, UserID: 2},
+		PausedAt:        &at,
+		PausedUntil:     &at,
+		UpdatedAt:       at,
+		IsSubscribed:    true,
+		DeletedAt:       &at,
+	})
+
+	assertJSON(t, reminder, `{"id":1,"user_id":2,"household_id":3,"body":"Water the plants",`+
+		`"start_time":"2026-10-19T09:30:00Z","is_repeating":true,"period_minutes":1440,"contact_method_id":4,`+
+		`"phone_number":"5551234567","email":"me@example.com","tags":["garden"],`+
+		`"excluded_times":["2026-10-20T09:30:00Z"],"rotation":[4,5],`+
+		`"current_assignee":{"position":0,"contact_method_id":4,"user_id":2},`+
+		`"paused_at":"2026-10-19T09:30:00Z","paused_until":"2026-10-19T09:30:00Z",`+
+		`"updated_at":"2026-10-19T09:30:00Z","is_subscribed":true,"deleted_at":"2026-10-19T09:30:00Z"}`)
+
+	cursor := "abc"
+	page := FromReminderPage(protocol.ReminderPage{Reminders: []protocol.Reminder{{ID: 1}}, NextCursor: &cursor})
+	if len(page.Reminders) != 1 || page.Reminders[0].ID != 1 || page.NextCursor != &cursor {
+		t.Errorf("got page %+v", page)
+	}
+}
+
+func TestContactMethodJSON(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 9, 30, 0, 0, time.UTC)
+	contactMethod := FromContactMethod(protocol.ContactMethod{
+		ID:          1,
+		UserID:      2,
+		Type:        "phone",
+		Value:       "5551234567",
+		Description: "Mobile",
+		DisabledAt:  &at,
+		UpdatedAt:   at,
+	})
+
+	assertJSON(t, contactMethod, `{"id":1,"user_id":2,"type":"phone","value":"5551234567","description":"Mobile",`+
+		`"disabled_at":"2026-10-19T09:30:00Z","updated_at":"2026-10-19T09:30:00Z"}`)
+}
+
+func TestRequestJSON(t *testing.T) {
+	body := `{"household_id":3,"body":"Water the plants","start_time":"2026-10-19T09:30:00Z","is_repeating":true,` +
+		`"period_minutes":1440,"contact_method_id":4,"phone_number":null,"email":null,"rotation":[4,5],` +
+		`"tags":["garden"],"excluded_times":["2026-10-20T09:30:00Z"]}`
+	assertRequest[CreateReminderRequest, protocol.CreateReminderRequest](t, body)
+	assertRequest[UpdateReminderRequest, protocol.UpdateReminderRequest](t, body)
+
+	contactMethodBody := `{"type":"email","value":"me@example.com","description":"Personal"}`
+	assertRequest[CreateContactMethodRequest, protocol.CreateContactMethodRequest](t, contactMethodBody)
+	assertRequest[UpdateContactMethodRequest, protocol.UpdateContactMethodRequest](t, contactMethodBody)
+}
+
+// assertRequest checks that body is exactly a V, and that converting it
+// carries every field over to the protocol type P.
+func assertRequest[V interface{ Protocol() P }, P any](t *testing.T, body string) {
+	t.Helper()
+	var req V
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, req, body)
+
+	var want P
+	if err := json.Unmarshal([]byte(body), &want); err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Protocol(); !reflect.DeepEqual(got, want) {
+		t.Errorf("converted %T to %+v, want %+v", req, got, want)
+	}
+}
+
+func assertJSON(t *testing.T, v any, want string) {
+	t.Helper()
+	got, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,146 @@
+package remindercontroller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+)
+
+const maxBatchSize = 100
+
+const (
+	batchStatusOK         = "ok"
+	batchStatusError      = "error"
+	batchStatusRolledBack = "rolled_back"
+)
+
+// errBatchFailed rolls back an atomic batch with a failed operation.
+var errBatchFailed = errors.New("batch failed")
+
+// BatchReminders runs every operation in one transaction, each under its own
+// savepoint so a failed operation can be undone without losing the others.
+// Job changes are made with the transaction. Errors other than invalid
+// operations abort the whole batch, but nothing after it commits does.
+func (rc *Controller) BatchReminders(a *actor.Actor, req *protocol.BatchRemindersRequest) (*protocol.BatchRemindersResponse, error) {
+	if len(req.Operations) == 0 {
+		return nil, errs.Invalid("operations is required")
+	}
+	if len(req.Operations) > maxBatchSize {
+		return nil, errs.Invalid(fmt.Sprintf("a batch can have at most %d operations", maxBatchSize))
+	}
+
+	results := make([]protocol.BatchReminderResult, len(req.Operations))
+	err := rc.withTx(func(txc *Controller, jobs *jobChanges) error {
+		failed := false
+		for i, op := range req.Operations {
+			results[i].Index = i
+
+			savepoint := fmt.Sprintf("batch_%d", i)
+			if err := txc.db.SavePoint(savepoint).Error; err != nil {
+				return err
+			}
+			snapshot := jobs.snapshot()
+
+			id, err := txc.applyOperation(jobs, a, op)
+			var opErr *errs.Error
+			if errors.As(err, &opErr) {
+				if err := txc.db.RollbackTo(savepoint).Error; err != nil {
+					return err
+				}
+				jobs.restore(snapshot)
+				failed = true
+
+				message := err.Error()
+				results[i].Status = batchStatusError
+				results[i].Error = &message
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			results[i].Status = batchStatusOK
+			results[i].ID = &id
+		}
+		if failed && req.Atomic {
+			return errBatchFailed
+		}
+		return nil
+	})
+
+	response := &protocol.BatchRemindersResponse{Committed: err == nil, Results: results}
+	if errors.Is(err, errBatchFailed) {
+		for i := range results {
+			if results[i].Status == batchStatusOK {
+				results[i].Status = batchStatusRolledBack
+			}
+		}
+		return response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range req.Operations {
+		if results[i].Status != batchStatusOK || op.Op == "delete" {
+			continue
+		}
+		reminder, err := rc.getReminder(a, *results[i].ID)
+		if err != nil {
+			log.Printf("Failed to read reminder %d after its batch committed: %v", *results[i].ID, err)
+			continue
+		}
+		results[i].Reminder = reminder
+	}
+	return response, nil
+}
+
+// applyOperation runs one batch operation and returns the ID of the reminder
+// it affected.
+func (rc *Controller) applyOperation(jobs *jobChanges, a *actor.Actor, op protocol.BatchReminderOperation) (int64, error) {
+	if op.Op == "create" {
+		if op.Create == nil {
+			return 0, errs.Invalid("create is required")
+		}
+		dbReminder, err := rc.create(jobs, a, op.Create)
+		if err != nil {
+			return 0, err
+		}
+		return int64(dbReminder.ID), nil
+	}
+
+	if op.ID == 0 {
+		return 0, errs.Invalid("id is required")
+	}
+	switch op.Op {
+	case "update":
+		if op.Update == nil {
+			return 0, errs.Invalid("update is required")
+		}
+		return op.ID, rc.updateReminder(jobs, a, op.ID, op.Update, op.IfMatch)
+	case "patch":
+		if op.Patch == nil {
+			return 0, errs.Invalid("patch is required")
+		}
+		patch, err := json.Marshal(op.Patch)
+		if err != nil {
+			return 0, err
+		}
+		return op.ID, rc.patchReminder(jobs, a, op.ID, patch, op.IfMatch)
+	case "delete":
+		return op.ID, rc.deleteReminder(jobs, a, op.ID)
+	case "pause":
+		req := op.Pause
+		if req == nil {
+			req = &protocol.PauseReminderRequest{}
+		}
+		return op.ID, rc.pauseReminder(jobs, a, op.ID, req)
+	case "resume":
+		return op.ID, rc.resumeReminder(jobs, a, op.ID)
+	default:
+		return 0, errs.Invalid(fmt.Sprintf("unknown op %q", op.Op))
+	}
+}
@@ -0,0 +1,149 @@
+package remindercontroller
+
+import (
+	"context"
+	"errors"
+	"log"
+	"maps"
+	"reminder-app/models"
+	"reminder-app/river/rivertx"
+	"reminder-app/workers"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"gorm.io/gorm"
+)
+
+// jobChanges collects the River changes made while editing reminders in a
+// transaction. Jobs are inserted and cancelled in the transaction just before
+// it commits, but periodic jobs live in memory and can't take part in it, so
+// they only change once it has.
+type jobChanges struct {
+	reminders map[uint]reminderJobChange
+	inserts   []river.InsertManyParams
+}
+
+// reminderJobChange coalesces every change to one reminder: the job it had
+// before the transaction is unscheduled and its final state is scheduled.
+type reminderJobChange struct {
+	// before is nil for reminders created in the transaction.
+	before *models.Reminder
+	// after is nil if the reminder shouldn't be scheduled, such as when it
+	// was deleted.
+	after *models.Reminder
+}
+
+func newJobChanges() *jobChanges {
+	return &jobChanges{reminders: map[uint]reminderJobChange{}}
+}
+
+func (j *jobChanges) created(after *models.Reminder) {
+	j.reminders[after.ID] = reminderJobChange{after: after}
+}
+
+func (j *jobChanges) changed(before models.Reminder, after *models.Reminder) {
+	change, ok := j.reminders[before.ID]
+	if !ok {
+		change.before = &before
+	}
+	change.after = after
+	j.reminders[before.ID] = change
+}
+
+func (j *jobChanges) insert(args river.JobArgs, opts *river.InsertOpts) {
+	j.inserts = append(j.inserts, river.InsertManyParams{Args: args, InsertOpts: opts})
+}
+
+// snapshot and restore undo the changes of an operation rolled back to a
+// savepoint.
+func (j *jobChanges) snapshot() *jobChanges {
+	return &jobChanges{reminders: maps.Clone(j.reminders), inserts: slices.Clone(j.inserts)}
+}
+
+func (j *jobChanges) restore(snapshot *jobChanges) {
+	*j = *snapshot
+}
+
+// applyTx makes the changes to jobs in the database as the last part of tx.
+func (j *jobChanges) applyTx(ctx context.Context, tx *gorm.DB, riverTx *rivertx.Tx, riverClient *river.Client[pgx.Tx]) error {
+	err := riverTx.Run(func(riverTx pgx.Tx) error {
+		for _, change := range j.reminders {
+			if change.before != nil {
+				if err := workers.UnscheduleReminderTx(ctx, riverClient, riverTx, *change.before); err != nil {
+					return err
+				}
+			}
+			if change.after != nil {
+				if err := workers.ScheduleReminderTx(ctx, riverClient, riverTx, change.after); err != nil {
+					return err
+				}
+			}
+		}
+		for _, params := range j.inserts {
+			if _, err := riverClient.InsertTx(ctx, riverTx, params.Args, params.InsertOpts); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, change := range j.reminders {
+		if change.after == nil || change.after.IsRepeating {
+			continue
+		}
+		// UpdateColumn leaves updated_at, and so the ETag, as the transaction
+		// set it.
+		if err := tx.Model(change.after).UpdateColumn("river_job_id", change.after.RiverJobID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPeriodic changes periodic jobs once the transaction has committed. It
+// carries on past failures so one bad job doesn't leave the rest unscheduled.
+func (j *jobChanges) applyPeriodic(ctx context.Context, db *gorm.DB, riverClient *river.Client[pgx.Tx]) error {
+	var errs []error
+	for _, change := range j.reminders {
+		if change.before != nil && change.before.IsRepeating {
+			workers.UnscheduleReminder(ctx, riverClient, *change.before)
+		}
+		if change.after == nil || !change.after.IsRepeating {
+			continue
+		}
+		if err := workers.ScheduleReminder(ctx, riverClient, change.after); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := db.Model(change.after).UpdateColumn("river_job_id", change.after.RiverJobID).Error; err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// withTx runs fn with a copy of the controller whose queries go through a
+// transaction, along with the job changes fn made. Once the transaction has
+// committed, failing to change periodic jobs is only logged, as the changes
+// themselves succeeded.
+func (rc *Controller) withTx(fn func(txc *Controller, jobs *jobChanges) error) error {
+	ctx := context.Background()
+	jobs := newJobChanges()
+	err := rivertx.Transaction(ctx, rc.db, func(tx *gorm.DB, riverTx *rivertx.Tx) error {
+		if err := fn(&Controller{db: tx, riverClient: rc.riverClient}, jobs); err != nil {
+			return err
+		}
+		return jobs.applyTx(ctx, tx, riverTx, rc.riverClient)
+	})
+	if err != nil {
+		return err
+	}
+	if err := jobs.applyPeriodic(ctx, rc.db, rc.riverClient); err != nil {
+		log.Printf("Failed to schedule periodic reminder jobs: %v", err)
+	}
+	return nil
+}
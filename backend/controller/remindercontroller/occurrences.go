@@ -0,0 +1,113 @@
+package remindercontroller
+
+import (
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"reminder-app/models"
+	"reminder-app/workers"
+	"sort"
+	"time"
+)
+
+const (
+	defaultOccurrenceWindow = 30 * 24 * time.Hour
+	maxOccurrenceWindow     = 366 * 24 * time.Hour
+	defaultOccurrenceLimit  = 100
+	maxOccurrenceLimit      = 1000
+)
+
+// GetOccurrences previews when a single reminder will fire.
+func (rc *Controller) GetOccurrences(a *actor.Actor, id int64, query *protocol.OccurrencesQuery) ([]protocol.Occurrence, error) {
+	from, to, limit, err := occurrenceBounds(query)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReminder, err := rc.findReminder(a, id)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := expand(*dbReminder, from, to, limit)
+	return occurrences, nil
+}
+
+// GetAgenda merges the upcoming occurrences of every reminder the actor can
+// see, including reminders they subscribe to, ordered by time.
+func (rc *Controller) GetAgenda(a *actor.Actor, query *protocol.OccurrencesQuery) ([]protocol.Occurrence, error) {
+	from, to, limit, err := occurrenceBounds(query)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, _, err := rc.visible(a)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbReminders []models.Reminder
+	err = rc.db.Where(visible).
+		Where("start_time < ?", to).
+		Where("is_repeating OR start_time >= ?", from).
+		Scopes(withRotation).
+		Find(&dbReminders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := []protocol.Occurrence{}
+	for _, dbReminder := range dbReminders {
+		occurrences = append(occurrences, expand(dbReminder, from, to, limit)...)
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].OccursAt.Before(occurrences[j].OccursAt)
+	})
+	if len(occurrences) > limit {
+		occurrences = occurrences[:limit]
+	}
+	return occurrences, nil
+}
+
+func occurrenceBounds(query *protocol.OccurrencesQuery) (time.Time, time.Time, int, error) {
+	from := time.Now()
+	if query.From != nil {
+		from = *query.From
+	}
+	to := from.Add(defaultOccurrenceWindow)
+	if query.To != nil {
+		to = *query.To
+	}
+	if !to.After(from) {
+		return from, to, 0, errs.Invalid("to must be after from")
+	}
+	if to.Sub(from) > maxOccurrenceWindow {
+		return from, to, 0, errs.Invalid("from and to can be at most 366 days apart")
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultOccurrenceLimit
+	}
+	return from, to, min(limit, maxOccurrenceLimit), nil
+}
+
+// expand turns a reminder into occurrences using the worker's schedule.
+// Rotating reminders move to the next assignee on every occurrence, starting
+// from the current one.
+func expand(dbReminder models.Reminder, from time.Time, to time.Time, limit int) []protocol.Occurrence {
+	occurrences := []protocol.Occurrence{}
+	for i, t := range workers.Occurrences(dbReminder, from, to, limit) {
+		contactMethodID := dbReminder.ContactMethodID
+		if slots := dbReminder.RotationSlots; len(slots) > 0 {
+			contactMethodID = slots[(dbReminder.RotationIndex+i)%len(slots)].ContactMethodID
+		}
+		occurrences = append(occurrences, protocol.Occurrence{
+			ReminderID:      int64(dbReminder.ID),
+			Body:            dbReminder.Body,
+			OccursAt:        t,
+			ContactMethodID: contactMethodID,
+		})
+	}
+	return occurrences
+}
@@ -0,0 +1,101 @@
+package remindercontroller
+
+import (
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"reminder-app/workers"
+	"time"
+
+	"github.com/riverqueue/river"
+)
+
+// PauseReminder stops a reminder from firing, until req.Until if given or
+// otherwise until it's resumed. Pausing a paused reminder changes when the
+// pause ends.
+func (rc *Controller) PauseReminder(a *actor.Actor, id int64, req *protocol.PauseReminderRequest) (*protocol.Reminder, error) {
+	err := rc.withTx(func(txc *Controller, jobs *jobChanges) error {
+		return txc.pauseReminder(jobs, a, id, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.getReminder(a, id)
+}
+
+func (rc *Controller) pauseReminder(jobs *jobChanges, a *actor.Actor, id int64, req *protocol.PauseReminderRequest) error {
+	now := time.Now()
+	if req.Until != nil && !req.Until.After(now) {
+		return errs.Invalid("until must be in the future")
+	}
+
+	dbReminder, err := rc.findReminder(a, id)
+	if err != nil {
+		return err
+	}
+	previous := *dbReminder
+
+	if dbReminder.PausedAt == nil {
+		dbReminder.PausedAt = &now
+	}
+	dbReminder.PausedUntil = req.Until
+	if err := rc.db.Model(dbReminder).Select("paused_at", "paused_until").Updates(dbReminder).Error; err != nil {
+		return err
+	}
+	if err := workers.PublishReminderEvent(rc.db, protocol.ReminderUpdated, *dbReminder); err != nil {
+		return err
+	}
+
+	// Unschedules the reminder's job, unless it was already paused.
+	jobs.changed(previous, dbReminder)
+	if req.Until != nil {
+		// The job checks PausedUntil, so one left over from an earlier pause
+		// does nothing.
+		jobs.insert(workers.ResumeReminderJobArgs{ReminderID: id}, &river.InsertOpts{
+			ScheduledAt: *req.Until,
+		})
+	}
+	return nil
+}
+
+// ResumeReminder ends a pause. Occurrences missed while paused aren't sent: a
+// repeating reminder picks up from its next occurrence, and a one-time
+// reminder whose time has passed stays unscheduled.
+func (rc *Controller) ResumeReminder(a *actor.Actor, id int64) (*protocol.Reminder, error) {
+	err := rc.withTx(func(txc *Controller, jobs *jobChanges) error {
+		return txc.resumeReminder(jobs, a, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.getReminder(a, id)
+}
+
+func (rc *Controller) resumeReminder(jobs *jobChanges, a *actor.Actor, id int64) error {
+	dbReminder, err := rc.findReminder(a, id)
+	if err != nil {
+		return err
+	}
+	if dbReminder.PausedAt == nil {
+		return errs.Invalid("reminder is not paused")
+	}
+	previous := *dbReminder
+
+	dbReminder.PausedAt = nil
+	dbReminder.PausedUntil = nil
+	if err := rc.db.Model(dbReminder).Select("paused_at", "paused_until").Updates(dbReminder).Error; err != nil {
+		return err
+	}
+	if err := workers.PublishReminderEvent(rc.db, protocol.ReminderUpdated, *dbReminder); err != nil {
+		return err
+	}
+
+	if dbReminder.IsRepeating || dbReminder.StartTime.After(time.Now()) {
+		jobs.changed(previous, dbReminder)
+	} else {
+		jobs.changed(previous, nil)
+	}
+	return nil
+}
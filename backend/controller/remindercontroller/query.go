@@ -0,0 +1,132 @@
+package remindercontroller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/models"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 500
+)
+
+var sortColumns = []string{"start_time", "created_at", "updated_at"}
+
+// cursor is the sort key and ID of the last reminder on a page, so the next
+// page can continue with a keyset comparison on (column, id).
+type cursor struct {
+	Value time.Time `json:"v"`
+	ID    uint      `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, errs.Invalid("invalid cursor")
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, errs.Invalid("invalid cursor")
+	}
+	return c, nil
+}
+
+func parseSort(sort string) (column string, desc bool, err error) {
+	if sort == "" {
+		return "start_time", false, nil
+	}
+	column, desc = strings.CutPrefix(sort, "-")
+	if !slices.Contains(sortColumns, column) {
+		return "", false, errs.Invalid("sort must be one of start_time, created_at or updated_at")
+	}
+	return column, desc, nil
+}
+
+func sortValue(dbReminder models.Reminder, column string) time.Time {
+	switch column {
+	case "created_at":
+		return dbReminder.CreatedAt
+	case "updated_at":
+		return dbReminder.UpdatedAt
+	default:
+		return dbReminder.StartTime
+	}
+}
+
+// applyFilters narrows a reminder query to the filters set in query.
+func applyFilters(db *gorm.DB, query *protocol.GetRemindersQuery) (*gorm.DB, error) {
+	switch query.Status {
+	case "":
+		if !query.IncludePast {
+			// For one-time reminders, exclude past ones. For repeating, always include
+			db = db.Where("is_repeating OR (start_time > ?)", time.Now())
+		}
+	case "upcoming":
+		db = db.Where("is_repeating OR (start_time > ?)", time.Now())
+	case "past":
+		db = db.Where("NOT is_repeating AND start_time <= ?", time.Now())
+	default:
+		return nil, errs.Invalid("status must be upcoming or past")
+	}
+
+	if query.Repeating != nil {
+		db = db.Where("is_repeating = ?", *query.Repeating)
+	}
+	if query.ContactMethodID != 0 {
+		db = db.Where("contact_method_id = ?", query.ContactMethodID)
+	}
+	if query.From != nil {
+		db = db.Where("start_time >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("start_time < ?", *query.To)
+	}
+	if query.Tag != "" {
+		tag, _ := json.Marshal([]string{query.Tag})
+		db = db.Where("tags @> ?::jsonb", string(tag))
+	}
+	if q := strings.TrimSpace(query.Q); q != "" {
+		db = db.Where("body_tsv @@ websearch_to_tsquery('english', ?)", q)
+	}
+	return db, nil
+}
+
+// normalizeTags trims and de-duplicates tags, always returning a non-nil slice
+// so the column stores [] rather than null.
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// normalizeExcludedTimes sorts and de-duplicates excluded occurrences in UTC,
+// always returning a non-nil slice.
+func normalizeExcludedTimes(times []time.Time) []time.Time {
+	normalized := []time.Time{}
+	for _, t := range times {
+		t = t.UTC()
+		if !slices.ContainsFunc(normalized, t.Equal) {
+			normalized = append(normalized, t)
+		}
+	}
+	slices.SortFunc(normalized, func(a, b time.Time) int { return a.Compare(b) })
+	return normalized
+}
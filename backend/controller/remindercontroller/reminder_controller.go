@@ -1,21 +1,30 @@
 package remindercontroller
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reminder-app/controller/errs"
 	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"reminder-app/lib/etag"
+	"reminder-app/lib/mergepatch"
 	"reminder-app/models"
 	"reminder-app/workers"
+	"slices"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/riverqueue/river"
-	"github.com/riverqueue/river/rivertype"
 	"go.uber.org/fx"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var errReminderChanged = errs.PreconditionFailed("reminder has changed since it was read")
+
 type Controller struct {
 	db          *gorm.DB
 	riverClient *river.Client[pgx.Tx]
@@ -32,199 +41,584 @@ func New(p Params) *Controller {
 	return &Controller{db: p.DB, riverClient: p.River}
 }
 
-func (rc *Controller) GetReminders(userID int64, includePast bool) ([]protocol.Reminder, error) {
-	var user models.User
-	if err := rc.db.Where("id = ?", userID).First(&user).Error; err != nil {
+func (rc *Controller) GetReminders(a *actor.Actor, query *protocol.GetRemindersQuery) (*protocol.ReminderPage, error) {
+	column, desc, err := parseSort(query.Sort)
+	if err != nil {
 		return nil, err
 	}
 
-	var dbReminders []models.Reminder
-	query := rc.db.Where("user_id = ?", user.ID)
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+
+	visible, subscribedIDs, err := rc.visible(a)
+	if err != nil {
+		return nil, err
+	}
 
-	if !includePast {
-		// For one-time reminders, exclude past ones. For repeating, always include
-		query = query.Where("is_repeating OR (start_time > ?)", time.Now())
+	db, err := applyFilters(rc.db.Where(visible), query)
+	if err != nil {
+		return nil, err
+	}
+
+	direction, comparison := "ASC", ">"
+	if desc {
+		direction, comparison = "DESC", "<"
+	}
+	if query.Cursor != "" {
+		c, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("("+column+", id) "+comparison+" (?, ?)", c.Value, c.ID)
 	}
 
-	err := query.Order("start_time").Find(&dbReminders).Error
+	// Fetch one extra row to know whether there is a next page.
+	var dbReminders []models.Reminder
+	err = db.Scopes(withRotation).
+		Order(column + " " + direction).
+		Order("id " + direction).
+		Limit(limit + 1).
+		Find(&dbReminders).Error
 	if err != nil {
 		return nil, err
 	}
 
-	var protocolReminders []protocol.Reminder
+	page := &protocol.ReminderPage{Reminders: []protocol.Reminder{}}
+	if len(dbReminders) > limit {
+		dbReminders = dbReminders[:limit]
+		last := dbReminders[limit-1]
+		next := encodeCursor(cursor{Value: sortValue(last, column), ID: last.ID})
+		page.NextCursor = &next
+	}
+
 	for _, dbReminder := range dbReminders {
-		protocolReminders = append(protocolReminders, protocol.Reminder{
-			ID:              int64(dbReminder.ID),
-			UserID:          dbReminder.UserID,
-			Body:            dbReminder.Body,
-			StartTime:       dbReminder.StartTime,
-			IsRepeating:     dbReminder.IsRepeating,
-			PeriodMinutes:   dbReminder.PeriodMinutes,
-			ContactMethodID: dbReminder.ContactMethodID,
-		})
+		protocolReminder := toProtocolReminder(dbReminder)
+		protocolReminder.IsSubscribed = slices.Contains(subscribedIDs, protocolReminder.ID) && !rc.canEdit(a, dbReminder)
+		page.Reminders = append(page.Reminders, protocolReminder)
+	}
+	return page, nil
+}
+
+// ListReminders returns every reminder visible to the actor, unpaginated, for
+// views that render the whole schedule such as the calendar feed.
+func (rc *Controller) ListReminders(a *actor.Actor) ([]models.Reminder, error) {
+	visible, _, err := rc.visible(a)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbReminders []models.Reminder
+	if err := rc.db.Where(visible).Order("start_time").Order("id").Find(&dbReminders).Error; err != nil {
+		return nil, err
 	}
-	return protocolReminders, err
+	return dbReminders, nil
 }
 
-func (rc *Controller) CreateReminder(userID int64, reminder *protocol.CreateReminderRequest) (*protocol.Reminder, error) {
-	var contactMethod models.ContactMethod
-	err := rc.db.Where("user_id = ? and id = ?", userID, reminder.ContactMethodID).First(&contactMethod).Error
+func (rc *Controller) CreateReminder(a *actor.Actor, reminder *protocol.CreateReminderRequest) (*protocol.Reminder, error) {
+	var dbReminder *models.Reminder
+	err := rc.withTx(func(txc *Controller, jobs *jobChanges) error {
+		var err error
+		dbReminder, err = txc.create(jobs, a, reminder)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.getReminder(a, int64(dbReminder.ID))
+}
+
+func (rc *Controller) create(jobs *jobChanges, a *actor.Actor, reminder *protocol.CreateReminderRequest) (*models.Reminder, error) {
+	if reminder.IsRepeating && reminder.PeriodMinutes <= 0 {
+		return nil, errs.Invalid("period minutes must be greater than 0")
+	}
+
+	contactMethodID, err := rc.validateTargets(a, reminder.HouseholdID, reminder.ContactMethodID, reminder.IsRepeating, reminder.Rotation)
 	if err != nil {
-		return nil, errors.New("contact method not found")
+		return nil, err
 	}
+	reminder.ContactMethodID = contactMethodID
 
 	dbReminder := &models.Reminder{
-		UserID:          userID,
+		UserID:          a.GetUserIDInt64(),
+		HouseholdID:     reminder.HouseholdID,
 		Body:            reminder.Body,
 		StartTime:       reminder.StartTime,
 		IsRepeating:     reminder.IsRepeating,
 		PeriodMinutes:   reminder.PeriodMinutes,
 		ContactMethodID: reminder.ContactMethodID,
+		Tags:            normalizeTags(reminder.Tags),
+		ExcludedTimes:   normalizeExcludedTimes(reminder.ExcludedTimes),
 	}
 
-	err = rc.db.Create(dbReminder).Error
-	if err != nil {
+	if err := rc.db.Create(dbReminder).Error; err != nil {
+		return nil, err
+	}
+	if err := replaceRotation(rc.db, dbReminder, reminder.Rotation); err != nil {
+		return nil, err
+	}
+	if err := workers.PublishReminderEvent(rc.db, protocol.ReminderCreated, *dbReminder); err != nil {
 		return nil, err
 	}
 
-	if reminder.IsRepeating && reminder.PeriodMinutes <= 0 {
-		return nil, errors.New("period minutes must be greater than 0")
+	jobs.created(dbReminder)
+	return dbReminder, nil
+}
+
+// GetReminder returns a reminder visible to the actor.
+func (rc *Controller) GetReminder(a *actor.Actor, id int64) (*protocol.Reminder, error) {
+	visible, subscribedIDs, err := rc.visible(a)
+	if err != nil {
+		return nil, err
 	}
 
-	if reminder.IsRepeating {
-		args := workers.ReminderJobArgs{
-			ReminderID: int(dbReminder.ID),
-		}
-		opts := &river.InsertOpts{
-			ScheduledAt: dbReminder.StartTime,
+	var dbReminder models.Reminder
+	if err := rc.db.Where(visible).Scopes(withRotation).Where("id = ?", id).First(&dbReminder).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NotFound("reminder not found")
 		}
-		job := river.NewPeriodicJob(
-			river.PeriodicInterval(time.Duration(dbReminder.PeriodMinutes)*time.Minute),
-			func() (river.JobArgs, *river.InsertOpts) { return args, opts },
-			nil,
-		)
+		return nil, err
+	}
 
-		// This adds a handle in memory, not in the database
-		handle := rc.riverClient.PeriodicJobs().Add(job)
+	reminder := toProtocolReminder(dbReminder)
+	reminder.IsSubscribed = slices.Contains(subscribedIDs, reminder.ID) && !rc.canEdit(a, dbReminder)
+	return &reminder, nil
+}
 
-		fmt.Println("🔄 ADDED PERIODIC JOB", handle)
-		dbReminder.RiverJobID = int(handle)
+// UpdateReminder replaces a reminder's fields. If ifMatch is set, the update
+// only succeeds if it matches the reminder's current ETag.
+func (rc *Controller) UpdateReminder(a *actor.Actor, id int64, reminder *protocol.UpdateReminderRequest, ifMatch string) (*protocol.Reminder, error) {
+	err := rc.withTx(func(txc *Controller, jobs *jobChanges) error {
+		return txc.updateReminder(jobs, a, id, reminder, ifMatch)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	} else {
-		args := workers.ReminderJobArgs{
-			ReminderID: int(dbReminder.ID),
-		}
-		opts := &river.InsertOpts{
-			ScheduledAt: dbReminder.StartTime,
-		}
-		insertResult, err := rc.riverClient.Insert(context.Background(), args, opts)
-		if err != nil {
-			return nil, err
-		}
+	return rc.getReminder(a, id)
+}
+
+func (rc *Controller) updateReminder(jobs *jobChanges, a *actor.Actor, id int64, reminder *protocol.UpdateReminderRequest, ifMatch string) error {
+	if reminder.IsRepeating && reminder.PeriodMinutes <= 0 {
+		return errs.Invalid("period minutes must be greater than 0")
+	}
 
-		dbReminder.RiverJobID = int(insertResult.Job.ID)
+	dbReminder, err := rc.findReminder(a, id)
+	if err != nil {
+		return err
+	}
 
+	if reminder.HouseholdID == nil {
+		reminder.HouseholdID = dbReminder.HouseholdID
+	}
+	if reminder.Rotation == nil {
+		reminder.Rotation = rotationOf(dbReminder.RotationSlots)
+	}
+	if reminder.Tags == nil {
+		reminder.Tags = dbReminder.Tags
+	}
+	if reminder.ExcludedTimes == nil {
+		reminder.ExcludedTimes = dbReminder.ExcludedTimes
 	}
 
-	err = rc.db.Save(dbReminder).Error
+	return rc.update(jobs, a, dbReminder, reminder, ifMatch)
+}
+
+// PatchReminder applies a JSON Merge Patch to the fields of
+// protocol.UpdateReminderRequest. Setting household_id to null moves a
+// household reminder back to its creator.
+func (rc *Controller) PatchReminder(a *actor.Actor, id int64, patch []byte, ifMatch string) (*protocol.Reminder, error) {
+	err := rc.withTx(func(txc *Controller, jobs *jobChanges) error {
+		return txc.patchReminder(jobs, a, id, patch, ifMatch)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &protocol.Reminder{
-		ID:              int64(dbReminder.ID),
+	return rc.getReminder(a, id)
+}
+
+func (rc *Controller) patchReminder(jobs *jobChanges, a *actor.Actor, id int64, patch []byte, ifMatch string) error {
+	dbReminder, err := rc.findReminder(a, id)
+	if err != nil {
+		return err
+	}
+	if !etag.Matches(ifMatch, dbReminder.UpdatedAt) {
+		return errReminderChanged
+	}
+
+	current, err := json.Marshal(protocol.UpdateReminderRequest{
+		HouseholdID:     dbReminder.HouseholdID,
 		Body:            dbReminder.Body,
 		StartTime:       dbReminder.StartTime,
 		IsRepeating:     dbReminder.IsRepeating,
 		PeriodMinutes:   dbReminder.PeriodMinutes,
 		ContactMethodID: dbReminder.ContactMethodID,
-	}, nil
+		Rotation:        rotationOf(dbReminder.RotationSlots),
+		Tags:            dbReminder.Tags,
+		ExcludedTimes:   dbReminder.ExcludedTimes,
+	})
+	if err != nil {
+		return err
+	}
+	merged, err := mergepatch.Apply(current, patch)
+	if err != nil {
+		return errs.Invalid(fmt.Sprintf("invalid patch: %v", err))
+	}
+
+	var reminder protocol.UpdateReminderRequest
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reminder); err != nil {
+		return errs.Invalid(fmt.Sprintf("invalid patch: %v", err))
+	}
+	// Lists are omitted when empty or removed by the patch; both mean none.
+	if reminder.Rotation == nil {
+		reminder.Rotation = []int64{}
+	}
+	if reminder.Tags == nil {
+		reminder.Tags = []string{}
+	}
+	if reminder.ExcludedTimes == nil {
+		reminder.ExcludedTimes = []time.Time{}
+	}
+
+	if reminder.IsRepeating && reminder.PeriodMinutes <= 0 {
+		return errs.Invalid("period minutes must be greater than 0")
+	}
+	if reminder.HouseholdID == nil && dbReminder.HouseholdID != nil && dbReminder.UserID != a.GetUserIDInt64() {
+		return errs.Forbidden("only the reminder's creator can take it out of the household")
+	}
+
+	return rc.update(jobs, a, dbReminder, &reminder, ifMatch)
 }
 
-func (rc *Controller) UpdateReminder(id int64, reminder *protocol.UpdateReminderRequest) (*protocol.Reminder, error) {
-	var dbReminder models.Reminder
-	if err := rc.db.Where("id = ?", id).First(&dbReminder).Error; err != nil {
-		return nil, err
+// update saves every field of reminder onto dbReminder and reschedules it.
+func (rc *Controller) update(jobs *jobChanges, a *actor.Actor, dbReminder *models.Reminder, reminder *protocol.UpdateReminderRequest, ifMatch string) error {
+	if ifMatch != "" {
+		// Check under a row lock so two updates with the same ETag can't both
+		// succeed.
+		var locked models.Reminder
+		err := rc.db.Clauses(clause.Locking{Strength: "UPDATE"}).Select("updated_at").Where("id = ?", dbReminder.ID).First(&locked).Error
+		if err != nil {
+			return err
+		}
+		if !etag.Matches(ifMatch, locked.UpdatedAt) {
+			return errReminderChanged
+		}
 	}
 
+	contactMethodID, err := rc.validateTargets(a, reminder.HouseholdID, reminder.ContactMethodID, reminder.IsRepeating, reminder.Rotation)
+	if err != nil {
+		return err
+	}
+	reminder.ContactMethodID = contactMethodID
+
+	previous := *dbReminder
+
 	// Update fields from request
+	dbReminder.HouseholdID = reminder.HouseholdID
 	dbReminder.Body = reminder.Body
 	dbReminder.StartTime = reminder.StartTime
 	dbReminder.IsRepeating = reminder.IsRepeating
 	dbReminder.PeriodMinutes = reminder.PeriodMinutes
 	dbReminder.ContactMethodID = reminder.ContactMethodID
+	dbReminder.Tags = normalizeTags(reminder.Tags)
+	dbReminder.ExcludedTimes = normalizeExcludedTimes(reminder.ExcludedTimes)
 
-	err := rc.db.Save(&dbReminder).Error
+	if !sameRotation(dbReminder.RotationSlots, reminder.Rotation) {
+		if err := replaceRotation(rc.db, dbReminder, reminder.Rotation); err != nil {
+			return err
+		}
+	} else if len(reminder.Rotation) > 0 {
+		// Keep whoever is currently up when only other fields were edited.
+		dbReminder.ContactMethodID = dbReminder.RotationSlots[dbReminder.RotationIndex].ContactMethodID
+	}
+	if err := rc.db.Omit("RotationSlots").Save(dbReminder).Error; err != nil {
+		return err
+	}
+	if err := workers.PublishReminderEvent(rc.db, protocol.ReminderUpdated, *dbReminder, previous); err != nil {
+		return err
+	}
+
+	jobs.changed(previous, dbReminder)
+	return nil
+}
+
+// SkipRotation passes the next occurrence of a rotating reminder to the
+// following assignee.
+func (rc *Controller) SkipRotation(a *actor.Actor, id int64) (*protocol.Reminder, error) {
+	dbReminder, err := rc.findReminder(a, id)
 	if err != nil {
 		return nil, err
 	}
+	if len(dbReminder.RotationSlots) == 0 {
+		return nil, errs.Invalid("reminder does not rotate")
+	}
 
-	if _, err := rc.riverClient.JobCancel(context.Background(), int64(dbReminder.RiverJobID)); err != nil {
+	err = rc.db.Transaction(func(tx *gorm.DB) error {
+		if err := workers.AdvanceRotation(tx, id, 1); err != nil {
+			return err
+		}
+		return workers.PublishReminderEvent(tx, protocol.ReminderUpdated, *dbReminder)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	if reminder.IsRepeating {
-		args := workers.ReminderJobArgs{
-			ReminderID: int(dbReminder.ID),
-		}
-		opts := &river.InsertOpts{
-			ScheduledAt: dbReminder.StartTime,
-		}
-		job := river.NewPeriodicJob(
-			river.PeriodicInterval(time.Duration(dbReminder.PeriodMinutes)*time.Minute),
-			func() (river.JobArgs, *river.InsertOpts) { return args, opts },
-			nil,
-		)
+	return rc.getReminder(a, id)
+}
 
-		// This adds a handle in memory, not in the database
-		handle := rc.riverClient.PeriodicJobs().Add(job)
+// SwapRotation exchanges two assignees' places in a reminder's rotation.
+func (rc *Controller) SwapRotation(a *actor.Actor, id int64, req *protocol.SwapRotationRequest) (*protocol.Reminder, error) {
+	dbReminder, err := rc.findReminder(a, id)
+	if err != nil {
+		return nil, err
+	}
 
-		fmt.Println("🔄 ADDED PERIODIC JOB", handle)
-		dbReminder.RiverJobID = int(handle)
+	slots := dbReminder.RotationSlots
+	if len(slots) == 0 {
+		return nil, errs.Invalid("reminder does not rotate")
+	}
+	if req.PositionA < 0 || req.PositionA >= len(slots) || req.PositionB < 0 || req.PositionB >= len(slots) {
+		return nil, errs.Invalid("position out of range")
+	}
 
-	} else {
-		args := workers.ReminderJobArgs{
-			ReminderID: int(dbReminder.ID),
+	slotA, slotB := slots[req.PositionA], slots[req.PositionB]
+	err = rc.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&slotA).Update("contact_method_id", slotB.ContactMethodID).Error; err != nil {
+			return err
 		}
-		opts := &river.InsertOpts{
-			ScheduledAt: dbReminder.StartTime,
+		if err := tx.Model(&slotB).Update("contact_method_id", slotA.ContactMethodID).Error; err != nil {
+			return err
 		}
-		insertResult, err := rc.riverClient.Insert(context.Background(), args, opts)
-		if err != nil {
-			return nil, err
+		// Re-point the reminder at whoever now holds the current position.
+		if err := workers.AdvanceRotation(tx, id, 0); err != nil {
+			return err
 		}
+		return workers.PublishReminderEvent(tx, protocol.ReminderUpdated, *dbReminder)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		dbReminder.RiverJobID = int(insertResult.Job.ID)
+	return rc.getReminder(a, id)
+}
 
+func (rc *Controller) DeleteReminder(a *actor.Actor, id int64) error {
+	return rc.withTx(func(txc *Controller, jobs *jobChanges) error {
+		return txc.deleteReminder(jobs, a, id)
+	})
+}
+
+func (rc *Controller) deleteReminder(jobs *jobChanges, a *actor.Actor, id int64) error {
+	reminder, err := rc.findReminder(a, id)
+	if err != nil {
+		return err
 	}
 
-	return &protocol.Reminder{
-		ID:              int64(dbReminder.ID),
-		UserID:          dbReminder.UserID,
-		Body:            dbReminder.Body,
-		StartTime:       dbReminder.StartTime,
-		IsRepeating:     dbReminder.IsRepeating,
-		PeriodMinutes:   dbReminder.PeriodMinutes,
-		ContactMethodID: dbReminder.ContactMethodID,
-	}, nil
+	// Delete the reminder from database
+	if err := rc.db.Delete(reminder).Error; err != nil {
+		return err
+	}
+	if err := workers.PublishReminderEvent(rc.db, protocol.ReminderDeleted, *reminder); err != nil {
+		return err
+	}
+
+	jobs.changed(*reminder, nil)
+	return nil
+}
+
+// accessible scopes a query to reminders the actor owns or shares through a household.
+func (rc *Controller) accessible(a *actor.Actor) *gorm.DB {
+	householdIDs := a.HouseholdIDs()
+	if len(householdIDs) == 0 {
+		return rc.db.Where("user_id = ?", a.GetUserIDInt64())
+	}
+	return rc.db.Where("user_id = ? OR household_id IN ?", a.GetUserIDInt64(), householdIDs)
+}
+
+// visible scopes a query to reminders the actor can access or is subscribed
+// to. It also returns the subscribed reminder IDs.
+func (rc *Controller) visible(a *actor.Actor) (*gorm.DB, []int64, error) {
+	var subscribedIDs []int64
+	err := rc.db.Model(&models.ReminderSubscription{}).Where("user_id = ?", a.GetUserIDInt64()).Pluck("reminder_id", &subscribedIDs).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	visible := rc.accessible(a)
+	if len(subscribedIDs) > 0 {
+		visible = rc.db.Where(visible).Or("id IN ?", subscribedIDs)
+	}
+	return visible, subscribedIDs, nil
+}
+
+func (rc *Controller) getReminder(a *actor.Actor, id int64) (*protocol.Reminder, error) {
+	dbReminder, err := rc.findReminder(a, id)
+	if err != nil {
+		return nil, err
+	}
+	result := toProtocolReminder(*dbReminder)
+	return &result, nil
 }
 
-func (rc *Controller) DeleteReminder(id int64) error {
+func (rc *Controller) canEdit(a *actor.Actor, dbReminder models.Reminder) bool {
+	if dbReminder.UserID == a.GetUserIDInt64() {
+		return true
+	}
+	return dbReminder.HouseholdID != nil && a.IsMember(*dbReminder.HouseholdID)
+}
+
+func (rc *Controller) findReminder(a *actor.Actor, id int64) (*models.Reminder, error) {
 	var reminder models.Reminder
-	if err := rc.db.Where("id = ?", id).First(&reminder).Error; err != nil {
-		return err
+	if err := rc.accessible(a).Scopes(withRotation).Where("id = ?", id).First(&reminder).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NotFound("reminder not found")
+		}
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// validateTargets validates the reminder's contact method, or every contact
+// method in its rotation, and returns the one the reminder should notify first.
+func (rc *Controller) validateTargets(a *actor.Actor, householdID *int64, contactMethodID int64, isRepeating bool, rotation []int64) (int64, error) {
+	if len(rotation) == 0 {
+		return contactMethodID, rc.validateTarget(a, householdID, contactMethodID)
+	}
+
+	if !isRepeating {
+		return 0, errs.Invalid("only repeating reminders can rotate")
+	}
+	for _, id := range rotation {
+		if err := rc.validateTarget(a, householdID, id); err != nil {
+			return 0, err
+		}
 	}
+	return rotation[0], nil
+}
 
-	if reminder.IsRepeating {
-		rc.riverClient.PeriodicJobs().Remove(rivertype.PeriodicJobHandle(reminder.RiverJobID))
+// validateTarget checks that a personal reminder notifies one of the actor's
+// own contact methods, and a household reminder one of a member's.
+func (rc *Controller) validateTarget(a *actor.Actor, householdID *int64, contactMethodID int64) error {
+	query := rc.db.Model(&models.ContactMethod{}).Where("id = ?", contactMethodID)
+	if householdID == nil {
+		query = query.Where("user_id = ?", a.GetUserIDInt64())
 	} else {
-		rc.riverClient.JobDelete(context.Background(), int64(reminder.RiverJobID))
+		if !a.IsMember(*householdID) {
+			return errs.Forbidden("not a member of this household")
+		}
+		members := rc.db.Model(&models.HouseholdMember{}).Select("user_id").Where("household_id = ?", *householdID)
+		query = query.Where("user_id IN (?)", members)
 	}
 
-	// Delete the reminder from database
-	if err := rc.db.Delete(&reminder).Error; err != nil {
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
 		return err
 	}
+	if count == 0 {
+		return errs.Invalid("contact method not found")
+	}
+	return nil
+}
 
+func (rc *Controller) scheduleJob(dbReminder *models.Reminder) error {
+	return workers.ScheduleReminder(context.Background(), rc.riverClient, dbReminder)
+}
+
+func (rc *Controller) unscheduleJob(dbReminder *models.Reminder) {
+	workers.UnscheduleReminder(context.Background(), rc.riverClient, *dbReminder)
+}
+
+// withRotation loads a reminder's rotation slots in order along with their
+// contact methods, which tell us who each assignee is.
+func withRotation(db *gorm.DB) *gorm.DB {
+	return db.Preload("RotationSlots", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("RotationSlots.ContactMethod")
+}
+
+func rotationOf(slots []models.RotationSlot) []int64 {
+	rotation := []int64{}
+	for _, slot := range slots {
+		rotation = append(rotation, slot.ContactMethodID)
+	}
+	return rotation
+}
+
+func sameRotation(slots []models.RotationSlot, rotation []int64) bool {
+	if len(slots) != len(rotation) {
+		return false
+	}
+	for i, slot := range slots {
+		if slot.ContactMethodID != rotation[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceRotation swaps out a reminder's rotation and restarts it from the first assignee.
+func replaceRotation(tx *gorm.DB, dbReminder *models.Reminder, rotation []int64) error {
+	if err := tx.Unscoped().Where("reminder_id = ?", dbReminder.ID).Delete(&models.RotationSlot{}).Error; err != nil {
+		return err
+	}
+
+	dbReminder.RotationIndex = 0
+	dbReminder.RotationSlots = nil
+	for i, contactMethodID := range rotation {
+		slot := models.RotationSlot{
+			ReminderID:      int64(dbReminder.ID),
+			Position:        i,
+			ContactMethodID: contactMethodID,
+		}
+		if err := tx.Create(&slot).Error; err != nil {
+			return err
+		}
+		dbReminder.RotationSlots = append(dbReminder.RotationSlots, slot)
+	}
 	return nil
 }
+
+func toProtocolReminder(dbReminder models.Reminder) protocol.Reminder {
+	var rotation []int64
+	var currentAssignee *protocol.RotationAssignee
+	for _, slot := range dbReminder.RotationSlots {
+		rotation = append(rotation, slot.ContactMethodID)
+		if slot.Position == dbReminder.RotationIndex {
+			currentAssignee = &protocol.RotationAssignee{
+				Position:        slot.Position,
+				ContactMethodID: slot.ContactMethodID,
+			}
+			if slot.ContactMethod != nil {
+				currentAssignee.UserID = slot.ContactMethod.UserID
+			}
+		}
+	}
+
+	reminder := protocol.Reminder{
+		ID:              int64(dbReminder.ID),
+		UserID:          dbReminder.UserID,
+		HouseholdID:     dbReminder.HouseholdID,
+		Body:            dbReminder.Body,
+		StartTime:       dbReminder.StartTime,
+		IsRepeating:     dbReminder.IsRepeating,
+		PeriodMinutes:   dbReminder.PeriodMinutes,
+		ContactMethodID: dbReminder.ContactMethodID,
+		Tags:            normalizeTags(dbReminder.Tags),
+		ExcludedTimes:   normalizeExcludedTimes(dbReminder.ExcludedTimes),
+		Rotation:        rotation,
+		CurrentAssignee: currentAssignee,
+		PausedAt:        dbReminder.PausedAt,
+		PausedUntil:     dbReminder.PausedUntil,
+		UpdatedAt:       dbReminder.UpdatedAt,
+	}
+	if dbReminder.DeletedAt.Valid {
+		reminder.DeletedAt = &dbReminder.DeletedAt.Time
+	}
+	return reminder
+}
@@ -0,0 +1,91 @@
+package remindercontroller
+
+import (
+	"errors"
+	"log"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"reminder-app/models"
+	"reminder-app/workers"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GetTrash lists deleted reminders the actor can restore, most recently
+// deleted first. They're purged once they've been in the trash for the
+// configured retention.
+func (rc *Controller) GetTrash(a *actor.Actor) ([]protocol.Reminder, error) {
+	var dbReminders []models.Reminder
+	err := rc.db.Unscoped().
+		Where(rc.accessible(a)).
+		Where("deleted_at IS NOT NULL").
+		Scopes(withRotation).
+		Order("deleted_at DESC").
+		Order("id").
+		Find(&dbReminders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := make([]protocol.Reminder, 0, len(dbReminders))
+	for _, dbReminder := range dbReminders {
+		reminders = append(reminders, toProtocolReminder(dbReminder))
+	}
+	return reminders, nil
+}
+
+// RestoreReminder takes a reminder out of the trash and schedules it again.
+// A one-time reminder whose time passed while it was deleted is restored
+// without being scheduled, so it doesn't fire late.
+func (rc *Controller) RestoreReminder(a *actor.Actor, id int64) (*protocol.Reminder, error) {
+	var dbReminder models.Reminder
+	err := rc.db.Unscoped().
+		Where(rc.accessible(a)).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Scopes(withRotation).
+		First(&dbReminder).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NotFound("reminder not found in trash")
+		}
+		return nil, err
+	}
+
+	// Its contact methods may have been deleted, or their owners may have
+	// left the household, since the reminder was.
+	_, err = rc.validateTargets(a, dbReminder.HouseholdID, dbReminder.ContactMethodID, dbReminder.IsRepeating, rotationOf(dbReminder.RotationSlots))
+	if err != nil {
+		return nil, err
+	}
+
+	dbReminder.DeletedAt = gorm.DeletedAt{}
+	dbReminder.RiverJobID = 0
+	// A timed pause that ran out while the reminder was in the trash is over.
+	if dbReminder.PausedUntil != nil && !dbReminder.PausedUntil.After(time.Now()) {
+		dbReminder.PausedAt = nil
+		dbReminder.PausedUntil = nil
+	}
+	if dbReminder.IsRepeating || dbReminder.StartTime.After(time.Now()) {
+		if err := rc.scheduleJob(&dbReminder); err != nil {
+			return nil, err
+		}
+	}
+
+	err = rc.db.Unscoped().Model(&dbReminder).Updates(map[string]any{
+		"deleted_at":   nil,
+		"river_job_id": dbReminder.RiverJobID,
+		"paused_at":    dbReminder.PausedAt,
+		"paused_until": dbReminder.PausedUntil,
+	}).Error
+	if err != nil {
+		rc.unscheduleJob(&dbReminder)
+		return nil, err
+	}
+	if err := workers.PublishReminderEvent(rc.db, protocol.ReminderCreated, dbReminder); err != nil {
+		log.Printf("Failed to publish restore of reminder %d: %v", dbReminder.ID, err)
+	}
+
+	return rc.getReminder(a, id)
+}
@@ -0,0 +1,64 @@
+package replycontroller
+
+import (
+	"context"
+	"reminder-app/lib/reply"
+	"reminder-app/models"
+	"reminder-app/workers"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// Controller applies the commands recipients reply to deliveries with,
+// whichever service the reply comes through. Callers check that the reply
+// came from the delivery's contact method.
+type Controller struct {
+	db          *gorm.DB
+	riverClient *river.Client[pgx.Tx]
+}
+
+type Params struct {
+	fx.In
+
+	DB    *gorm.DB
+	River *river.Client[pgx.Tx]
+}
+
+func New(p Params) *Controller {
+	return &Controller{db: p.DB, riverClient: p.River}
+}
+
+// Apply carries out cmd for delivery and returns a confirmation to send
+// back to the recipient.
+func (ctrl *Controller) Apply(ctx context.Context, delivery models.Delivery, cmd reply.Command) (string, error) {
+	switch cmd.Action {
+	case reply.Done:
+		// Earlier deliveries to the same contact method are done too, which
+		// stops every snooze of the reminder there.
+		err := ctrl.db.Model(&models.Delivery{}).
+			Where("reminder_id = ? AND contact_method_id = ? AND id <= ? AND acknowledged_at IS NULL",
+				delivery.ReminderID, delivery.ContactMethodID, delivery.ID).
+			Update("acknowledged_at", time.Now()).Error
+		if err != nil {
+			return "", err
+		}
+		return "Marked done.", nil
+
+	case reply.Snooze:
+		_, err := ctrl.riverClient.Insert(ctx, workers.ReminderJobArgs{
+			ReminderID: int(delivery.ReminderID),
+			DeliveryID: int64(delivery.ID),
+		}, &river.InsertOpts{
+			ScheduledAt: time.Now().Add(cmd.Snooze),
+		})
+		if err != nil {
+			return "", err
+		}
+		return "Snoozed for " + reply.FormatDuration(cmd.Snooze) + ".", nil
+	}
+	return "", reply.ErrUnknownCommand
+}
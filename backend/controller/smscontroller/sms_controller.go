@@ -0,0 +1,122 @@
+package smscontroller
+
+import (
+	"context"
+	"errors"
+	"reminder-app/controller/replycontroller"
+	"reminder-app/lib/channel/twilio"
+	"reminder-app/lib/reply"
+	"reminder-app/models"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// Carriers require these keywords to work on their own, ignoring case. The
+// SMS provider confirms opt-outs and opt-ins itself, so they aren't replied
+// to here.
+var (
+	optOutKeywords = []string{"stop", "stopall", "unsubscribe", "cancel", "end", "quit", "optout", "revoke"}
+	optInKeywords  = []string{"start", "unstop", "yes"}
+	helpKeywords   = []string{"help", "info"}
+)
+
+const helpText = "Reply DONE when you've done a reminder, or SNOOZE and how long, such as SNOOZE 30 for 30 minutes. Reply STOP to stop getting reminders by text."
+
+// Controller handles texts sent to the app's phone number.
+type Controller struct {
+	db      *gorm.DB
+	replies *replycontroller.Controller
+}
+
+type Params struct {
+	fx.In
+
+	DB      *gorm.DB
+	Replies *replycontroller.Controller
+}
+
+func New(p Params) *Controller {
+	return &Controller{db: p.DB, replies: p.Replies}
+}
+
+// HandleMessage handles a text from the phone number from and returns the
+// text to reply with, if any. Texts from numbers that aren't a contact method
+// are ignored.
+func (ctrl *Controller) HandleMessage(ctx context.Context, from string, body string) (string, error) {
+	keyword := strings.ToLower(strings.Trim(strings.TrimSpace(body), ".!"))
+	switch {
+	case slices.Contains(optOutKeywords, keyword):
+		// Opting out covers every contact method with the number, whoever
+		// added it, and is copied to any added later.
+		return "", ctrl.db.Model(&models.ContactMethod{}).Scopes(withNumber(from)).
+			Where("disabled_at IS NULL").
+			Update("disabled_at", time.Now()).Error
+	case slices.Contains(optInKeywords, keyword):
+		return "", ctrl.db.Model(&models.ContactMethod{}).Scopes(withNumber(from)).
+			Where("disabled_at IS NOT NULL").
+			Update("disabled_at", nil).Error
+	}
+
+	var contactMethods []models.ContactMethod
+	err := ctrl.db.Scopes(withNumber(from)).Where("deleted_at IS NULL").Find(&contactMethods).Error
+	if err != nil {
+		return "", err
+	}
+	if len(contactMethods) == 0 {
+		return "", nil
+	}
+	if slices.Contains(helpKeywords, keyword) {
+		return helpText, nil
+	}
+
+	// Nothing can be texted back to a number that has opted out.
+	contactMethods = slices.DeleteFunc(contactMethods, func(contactMethod models.ContactMethod) bool {
+		return contactMethod.DisabledAt != nil
+	})
+	if len(contactMethods) == 0 {
+		return "", nil
+	}
+
+	cmd, err := reply.Parse(body)
+	if errors.Is(err, reply.ErrUnknownCommand) {
+		return helpText, nil
+	}
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	// A text can't say which reminder it answers, so it answers the last one
+	// sent to the number.
+	var delivery models.Delivery
+	err = ctrl.db.Where("contact_method_id IN ?", ids(contactMethods)).Order("id DESC").First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "There's no reminder to reply to.", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return ctrl.replies.Apply(ctx, delivery, cmd)
+}
+
+// withNumber scopes a query to the phone contact methods with number,
+// including deleted ones, so that an opt-out outlives the contact methods it
+// was made through.
+func withNumber(number string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().
+			Where("type = ? AND regexp_replace(value, '[^0-9]', '', 'g') IN ?", models.ContactTypePhone, twilio.NumberVariants(number))
+	}
+}
+
+func ids(contactMethods []models.ContactMethod) []uint {
+	ids := make([]uint, len(contactMethods))
+	for i, contactMethod := range contactMethods {
+		ids[i] = contactMethod.ID
+	}
+	return ids
+}
@@ -0,0 +1,161 @@
+package telegramcontroller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"log"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/controller/replycontroller"
+	"reminder-app/lib/auth/pat"
+	"reminder-app/lib/channel/telegram"
+	"reminder-app/lib/reply"
+	"reminder-app/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// linkCodeTTL is how long a link code can be sent to the bot.
+const linkCodeTTL = 15 * time.Minute
+
+var errTelegramDisabled = errs.Unavailable("Telegram isn't configured on this server")
+
+type Controller struct {
+	db      *gorm.DB
+	bot     *telegram.Bot
+	replies *replycontroller.Controller
+}
+
+type Params struct {
+	fx.In
+
+	DB *gorm.DB
+	// Bot is nil when Telegram isn't configured.
+	Bot     *telegram.Bot
+	Replies *replycontroller.Controller
+}
+
+func New(p Params) *Controller {
+	return &Controller{db: p.DB, bot: p.Bot, replies: p.Replies}
+}
+
+// CreateLink returns a link that opens a chat with the bot, whose /start
+// message links the chat to the user as a telegram contact method.
+func (ctrl *Controller) CreateLink(userID int64) (*protocol.TelegramLink, error) {
+	if ctrl.bot == nil {
+		return nil, errTelegramDisabled
+	}
+
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+
+	linkCode := models.TelegramLinkCode{
+		UserID:    userID,
+		CodeHash:  pat.Hash(code),
+		ExpiresAt: time.Now().Add(linkCodeTTL),
+	}
+	if err := ctrl.db.Create(&linkCode).Error; err != nil {
+		return nil, err
+	}
+	return &protocol.TelegramLink{URL: ctrl.bot.Link(code), ExpiresAt: linkCode.ExpiresAt}, nil
+}
+
+// HandleUpdate handles a message or button press posted to the webhook.
+func (ctrl *Controller) HandleUpdate(ctx context.Context, update *telegram.Update) error {
+	if ctrl.bot == nil {
+		return errTelegramDisabled
+	}
+
+	switch {
+	case update.CallbackQuery != nil:
+		return ctrl.handleCallback(ctx, update.CallbackQuery)
+	case update.Message != nil:
+		if command, code := update.Message.Command(); command == "/start" {
+			return ctrl.handleStart(ctx, update.Message, code)
+		}
+	}
+	return nil
+}
+
+func (ctrl *Controller) handleStart(ctx context.Context, message *telegram.Message, code string) error {
+	chatID := message.Chat.ID
+	if code == "" {
+		return ctrl.bot.Reply(ctx, chatID, "To get reminders here, choose Connect Telegram on the contact methods page of the app.")
+	}
+
+	err := ctrl.db.Transaction(func(tx *gorm.DB) error {
+		// Each code links one chat.
+		var linkCode models.TelegramLinkCode
+		err := tx.Where("code_hash = ? AND expires_at > ?", pat.Hash(code), time.Now()).First(&linkCode).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&linkCode).Error; err != nil {
+			return err
+		}
+
+		// Linking a chat again keeps the contact method it already has.
+		contactMethod := models.ContactMethod{
+			UserID:      linkCode.UserID,
+			Type:        models.ContactTypeTelegram,
+			Value:       strconv.FormatInt(chatID, 10),
+			Description: message.Chat.Name(),
+		}
+		return tx.Where("user_id = ? AND type = ? AND value = ?", contactMethod.UserID, contactMethod.Type, contactMethod.Value).
+			FirstOrCreate(&contactMethod).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ctrl.bot.Reply(ctx, chatID, "That link has expired. Choose Connect Telegram in the app again for a new one.")
+	}
+	if err != nil {
+		return err
+	}
+	return ctrl.bot.Reply(ctx, chatID, "This chat is linked. Choose it as a reminder's contact method to get the reminder here.")
+}
+
+// handleCallback applies a Done or Snooze button, if it was pressed in the
+// chat its delivery was sent to.
+func (ctrl *Controller) handleCallback(ctx context.Context, query *telegram.CallbackQuery) error {
+	deliveryID, cmd, err := reply.ParseButtonData(query.Data)
+	if err != nil {
+		return ctrl.bot.AnswerCallbackQuery(ctx, query.ID, err.Error())
+	}
+	// Telegram leaves out messages too old to edit.
+	if query.Message == nil {
+		return ctrl.bot.AnswerCallbackQuery(ctx, query.ID, "This reminder is too old to reply to.")
+	}
+
+	var delivery models.Delivery
+	var contactMethod models.ContactMethod
+	err = ctrl.db.First(&delivery, deliveryID).Error
+	if err == nil {
+		err = ctrl.db.First(&contactMethod, delivery.ContactMethodID).Error
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err != nil || contactMethod.Type != models.ContactTypeTelegram || contactMethod.Value != strconv.FormatInt(query.Message.Chat.ID, 10) {
+		return ctrl.bot.AnswerCallbackQuery(ctx, query.ID, "This reminder is no longer sent to this chat.")
+	}
+
+	confirmation, err := ctrl.replies.Apply(ctx, delivery, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctrl.bot.AnswerCallbackQuery(ctx, query.ID, confirmation); err != nil {
+		return err
+	}
+	if err := ctrl.bot.RemoveButtons(ctx, query.Message); err != nil {
+		log.Printf("Failed to remove the buttons from Telegram message %d: %v", query.Message.MessageID, err)
+	}
+	return nil
+}
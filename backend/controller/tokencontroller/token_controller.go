@@ -0,0 +1,111 @@
+package tokencontroller
+
+import (
+	"errors"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/auth"
+	"reminder-app/lib/auth/pat"
+	"reminder-app/models"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const maxExpiresInDays = 365
+
+type Controller struct {
+	db *gorm.DB
+}
+
+type Params struct {
+	fx.In
+
+	DB *gorm.DB
+}
+
+func New(p Params) *Controller {
+	return &Controller{db: p.DB}
+}
+
+func (ctrl *Controller) GetTokens(userID int64) ([]protocol.APIToken, error) {
+	var dbTokens []models.APIToken
+	err := ctrl.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&dbTokens).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var protocolTokens []protocol.APIToken
+	for _, dbToken := range dbTokens {
+		protocolTokens = append(protocolTokens, toProtocolToken(dbToken))
+	}
+	return protocolTokens, nil
+}
+
+func (ctrl *Controller) CreateToken(userID int64, req *protocol.CreateAPITokenRequest) (*protocol.CreateAPITokenResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errs.Invalid("name is required")
+	}
+	if len(req.Scopes) == 0 {
+		return nil, errs.Invalid("at least one scope is required")
+	}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(auth.Scopes, scope) {
+			return nil, errs.Invalid("unknown scope: " + scope)
+		}
+	}
+	if req.ExpiresInDays <= 0 || req.ExpiresInDays > maxExpiresInDays {
+		return nil, errs.Invalid("expires_in_days must be between 1 and 365")
+	}
+
+	token, hash, err := pat.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	dbToken := &models.APIToken{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    token[:len(pat.TokenPrefix)+4],
+		TokenHash: hash,
+		Scopes:    req.Scopes,
+		ExpiresAt: time.Now().AddDate(0, 0, req.ExpiresInDays),
+	}
+	if err := ctrl.db.Create(dbToken).Error; err != nil {
+		return nil, err
+	}
+
+	return &protocol.CreateAPITokenResponse{
+		APIToken: toProtocolToken(*dbToken),
+		Token:    token,
+	}, nil
+}
+
+func (ctrl *Controller) DeleteToken(userID int64, id int64) error {
+	var dbToken models.APIToken
+	if err := ctrl.db.Where("user_id = ? AND id = ?", userID, id).First(&dbToken).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.NotFound("api token not found")
+		}
+		return err
+	}
+
+	// Hard delete so the hash can never authenticate again.
+	return ctrl.db.Unscoped().Delete(&dbToken).Error
+}
+
+func toProtocolToken(dbToken models.APIToken) protocol.APIToken {
+	return protocol.APIToken{
+		ID:         int64(dbToken.ID),
+		Name:       dbToken.Name,
+		Prefix:     dbToken.Prefix,
+		Scopes:     dbToken.Scopes,
+		ExpiresAt:  dbToken.ExpiresAt,
+		LastUsedAt: dbToken.LastUsedAt,
+		CreatedAt:  dbToken.CreatedAt,
+	}
+}
@@ -0,0 +1,30 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610190900 = NewMigrationPlan("202610190900", Up202610190900, Down202610190900)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610190900.ID
+	}) {
+		panic("Plan202610190900 is not registered")
+	}
+}
+
+// Up202610190900 creates the api_tokens table for personal access tokens
+func Up202610190900(tx *gorm.DB) error {
+	return tx.AutoMigrate(&models.APIToken{})
+}
+
+// Down202610190900 drops the api_tokens table
+func Down202610190900(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&models.APIToken{})
+}
@@ -0,0 +1,42 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610191000 = NewMigrationPlan("202610191000", Up202610191000, Down202610191000)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610191000.ID
+	}) {
+		panic("Plan202610191000 is not registered")
+	}
+}
+
+// Up202610191000 creates households and their members, and lets reminders belong to a household
+func Up202610191000(tx *gorm.DB) error {
+	return tx.AutoMigrate(
+		&models.Household{},
+		&models.HouseholdMember{},
+		&models.Reminder{},
+	)
+}
+
+// Down202610191000 drops households and the reminders.household_id column
+func Down202610191000(tx *gorm.DB) error {
+	if err := tx.Migrator().DropColumn(&models.Reminder{}, "household_id"); err != nil {
+		return err
+	}
+
+	if err := tx.Migrator().DropTable(&models.HouseholdMember{}); err != nil {
+		return err
+	}
+
+	return tx.Migrator().DropTable(&models.Household{})
+}
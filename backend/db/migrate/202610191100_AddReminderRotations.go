@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610191100 = NewMigrationPlan("202610191100", Up202610191100, Down202610191100)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610191100.ID
+	}) {
+		panic("Plan202610191100 is not registered")
+	}
+}
+
+// Up202610191100 adds rotation slots and the rotation index to reminders
+func Up202610191100(tx *gorm.DB) error {
+	return tx.AutoMigrate(
+		&models.Reminder{},
+		&models.RotationSlot{},
+	)
+}
+
+// Down202610191100 drops rotation slots and the rotation index
+func Down202610191100(tx *gorm.DB) error {
+	if err := tx.Migrator().DropTable(&models.RotationSlot{}); err != nil {
+		return err
+	}
+
+	return tx.Migrator().DropColumn(&models.Reminder{}, "rotation_index")
+}
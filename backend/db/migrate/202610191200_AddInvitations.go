@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610191200 = NewMigrationPlan("202610191200", Up202610191200, Down202610191200)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610191200.ID
+	}) {
+		panic("Plan202610191200 is not registered")
+	}
+}
+
+// Up202610191200 creates invitations and reminder subscriptions
+func Up202610191200(tx *gorm.DB) error {
+	return tx.AutoMigrate(
+		&models.Invitation{},
+		&models.ReminderSubscription{},
+	)
+}
+
+// Down202610191200 drops invitations and reminder subscriptions
+func Down202610191200(tx *gorm.DB) error {
+	if err := tx.Migrator().DropTable(&models.ReminderSubscription{}); err != nil {
+		return err
+	}
+
+	return tx.Migrator().DropTable(&models.Invitation{})
+}
@@ -0,0 +1,62 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610191300 = NewMigrationPlan("202610191300", Up202610191300, Down202610191300)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610191300.ID
+	}) {
+		panic("Plan202610191300 is not registered")
+	}
+}
+
+// Up202610191300 adds reminder tags and a full-text search index on the reminder body
+func Up202610191300(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&models.Reminder{}); err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_reminders_tags ON reminders USING GIN (tags)`).Error; err != nil {
+		return err
+	}
+
+	// Kept out of models.Reminder since it is derived and only used for filtering
+	if err := tx.Exec(`
+		ALTER TABLE reminders
+		ADD COLUMN IF NOT EXISTS body_tsv tsvector
+		GENERATED ALWAYS AS (to_tsvector('english', coalesce(body, ''))) STORED
+	`).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_reminders_body_tsv ON reminders USING GIN (body_tsv)`).Error; err != nil {
+		return err
+	}
+
+	// Keyset pagination orders by (start_time, id) within a user's reminders
+	return tx.Exec(`CREATE INDEX IF NOT EXISTS idx_reminders_user_start_time_id ON reminders (user_id, start_time, id)`).Error
+}
+
+// Down202610191300 drops the search indexes, body_tsv and tags
+func Down202610191300(tx *gorm.DB) error {
+	for _, index := range []string{"idx_reminders_user_start_time_id", "idx_reminders_body_tsv", "idx_reminders_tags"} {
+		if err := tx.Exec("DROP INDEX IF EXISTS " + index).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec("ALTER TABLE reminders DROP COLUMN IF EXISTS body_tsv").Error; err != nil {
+		return err
+	}
+
+	return tx.Migrator().DropColumn(&models.Reminder{}, "tags")
+}
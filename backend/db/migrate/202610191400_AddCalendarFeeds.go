@@ -0,0 +1,30 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610191400 = NewMigrationPlan("202610191400", Up202610191400, Down202610191400)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610191400.ID
+	}) {
+		panic("Plan202610191400 is not registered")
+	}
+}
+
+// Up202610191400 creates the calendar_feeds table for iCalendar subscriptions
+func Up202610191400(tx *gorm.DB) error {
+	return tx.AutoMigrate(&models.CalendarFeed{})
+}
+
+// Down202610191400 drops the calendar_feeds table
+func Down202610191400(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&models.CalendarFeed{})
+}
@@ -0,0 +1,30 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610191500 = NewMigrationPlan("202610191500", Up202610191500, Down202610191500)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610191500.ID
+	}) {
+		panic("Plan202610191500 is not registered")
+	}
+}
+
+// Up202610191500 adds excluded_times to reminders for skipped occurrences
+func Up202610191500(tx *gorm.DB) error {
+	return tx.AutoMigrate(&models.Reminder{})
+}
+
+// Down202610191500 drops excluded_times from reminders
+func Down202610191500(tx *gorm.DB) error {
+	return tx.Migrator().DropColumn(&models.Reminder{}, "excluded_times")
+}
@@ -0,0 +1,30 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610191600 = NewMigrationPlan("202610191600", Up202610191600, Down202610191600)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610191600.ID
+	}) {
+		panic("Plan202610191600 is not registered")
+	}
+}
+
+// Up202610191600 creates the deliveries and data_exports tables
+func Up202610191600(tx *gorm.DB) error {
+	return tx.AutoMigrate(&models.Delivery{}, &models.DataExport{})
+}
+
+// Down202610191600 drops the deliveries and data_exports tables
+func Down202610191600(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&models.DataExport{}, &models.Delivery{})
+}
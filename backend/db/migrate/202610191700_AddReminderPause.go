@@ -0,0 +1,35 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610191700 = NewMigrationPlan("202610191700", Up202610191700, Down202610191700)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610191700.ID
+	}) {
+		panic("Plan202610191700 is not registered")
+	}
+}
+
+// Up202610191700 adds paused_at and paused_until to reminders
+func Up202610191700(tx *gorm.DB) error {
+	return tx.AutoMigrate(&models.Reminder{})
+}
+
+// Down202610191700 drops the pause columns from reminders
+func Down202610191700(tx *gorm.DB) error {
+	for _, column := range []string{"paused_at", "paused_until"} {
+		if err := tx.Migrator().DropColumn(&models.Reminder{}, column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
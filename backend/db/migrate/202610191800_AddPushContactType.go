@@ -0,0 +1,41 @@
+package migrate
+
+import (
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610191800 = NewMigrationPlan("202610191800", Up202610191800, Down202610191800)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610191800.ID
+	}) {
+		panic("Plan202610191800 is not registered")
+	}
+}
+
+// Up202610191800 adds push to the contact_type enum
+func Up202610191800(tx *gorm.DB) error {
+	return tx.Exec("ALTER TYPE contact_type ADD VALUE IF NOT EXISTS 'push'").Error
+}
+
+// Down202610191800 deletes push contact methods and recreates contact_type
+// without push, since Postgres can't drop a value from an enum
+func Down202610191800(tx *gorm.DB) error {
+	for _, statement := range []string{
+		`DELETE FROM contact_methods WHERE type = 'push'`,
+		`ALTER TYPE contact_type RENAME TO contact_type_old`,
+		`CREATE TYPE contact_type AS ENUM ('phone', 'email')`,
+		`ALTER TABLE contact_methods ALTER COLUMN type TYPE contact_type USING type::text::contact_type`,
+		`DROP TYPE contact_type_old`,
+	} {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
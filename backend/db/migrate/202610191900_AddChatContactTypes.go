@@ -0,0 +1,46 @@
+package migrate
+
+import (
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610191900 = NewMigrationPlan("202610191900", Up202610191900, Down202610191900)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610191900.ID
+	}) {
+		panic("Plan202610191900 is not registered")
+	}
+}
+
+// Up202610191900 adds slack and discord to the contact_type enum
+func Up202610191900(tx *gorm.DB) error {
+	for _, value := range []string{"slack", "discord"} {
+		if err := tx.Exec("ALTER TYPE contact_type ADD VALUE IF NOT EXISTS '" + value + "'").Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down202610191900 deletes slack and discord contact methods and recreates
+// contact_type without them
+func Down202610191900(tx *gorm.DB) error {
+	for _, statement := range []string{
+		`DELETE FROM contact_methods WHERE type IN ('slack', 'discord')`,
+		`ALTER TYPE contact_type RENAME TO contact_type_old`,
+		`CREATE TYPE contact_type AS ENUM ('phone', 'email', 'push')`,
+		`ALTER TABLE contact_methods ALTER COLUMN type TYPE contact_type USING type::text::contact_type`,
+		`DROP TYPE contact_type_old`,
+	} {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
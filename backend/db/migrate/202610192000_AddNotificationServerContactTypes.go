@@ -0,0 +1,46 @@
+package migrate
+
+import (
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610192000 = NewMigrationPlan("202610192000", Up202610192000, Down202610192000)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610192000.ID
+	}) {
+		panic("Plan202610192000 is not registered")
+	}
+}
+
+// Up202610192000 adds ntfy and gotify to the contact_type enum
+func Up202610192000(tx *gorm.DB) error {
+	for _, value := range []string{"ntfy", "gotify"} {
+		if err := tx.Exec("ALTER TYPE contact_type ADD VALUE IF NOT EXISTS '" + value + "'").Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down202610192000 deletes ntfy and gotify contact methods and recreates
+// contact_type without them
+func Down202610192000(tx *gorm.DB) error {
+	for _, statement := range []string{
+		`DELETE FROM contact_methods WHERE type IN ('ntfy', 'gotify')`,
+		`ALTER TYPE contact_type RENAME TO contact_type_old`,
+		`CREATE TYPE contact_type AS ENUM ('phone', 'email', 'push', 'slack', 'discord')`,
+		`ALTER TABLE contact_methods ALTER COLUMN type TYPE contact_type USING type::text::contact_type`,
+		`DROP TYPE contact_type_old`,
+	} {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610192100 = NewMigrationPlan("202610192100", Up202610192100, Down202610192100)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610192100.ID
+	}) {
+		panic("Plan202610192100 is not registered")
+	}
+}
+
+// Up202610192100 adds telegram to the contact_type enum, creates the
+// telegram_link_codes table and adds acknowledged_at to deliveries
+func Up202610192100(tx *gorm.DB) error {
+	if err := tx.Exec("ALTER TYPE contact_type ADD VALUE IF NOT EXISTS 'telegram'").Error; err != nil {
+		return err
+	}
+	return tx.AutoMigrate(&models.TelegramLinkCode{}, &models.Delivery{})
+}
+
+// Down202610192100 drops acknowledged_at and the telegram_link_codes table,
+// deletes telegram contact methods and recreates contact_type without them
+func Down202610192100(tx *gorm.DB) error {
+	if err := tx.Migrator().DropColumn(&models.Delivery{}, "acknowledged_at"); err != nil {
+		return err
+	}
+	if err := tx.Migrator().DropTable(&models.TelegramLinkCode{}); err != nil {
+		return err
+	}
+	for _, statement := range []string{
+		`DELETE FROM contact_methods WHERE type = 'telegram'`,
+		`ALTER TYPE contact_type RENAME TO contact_type_old`,
+		`CREATE TYPE contact_type AS ENUM ('phone', 'email', 'push', 'slack', 'discord', 'ntfy', 'gotify')`,
+		`ALTER TABLE contact_methods ALTER COLUMN type TYPE contact_type USING type::text::contact_type`,
+		`DROP TYPE contact_type_old`,
+	} {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
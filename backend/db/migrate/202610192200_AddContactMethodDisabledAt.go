@@ -0,0 +1,30 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610192200 = NewMigrationPlan("202610192200", Up202610192200, Down202610192200)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610192200.ID
+	}) {
+		panic("Plan202610192200 is not registered")
+	}
+}
+
+// Up202610192200 adds disabled_at to contact_methods
+func Up202610192200(tx *gorm.DB) error {
+	return tx.AutoMigrate(&models.ContactMethod{})
+}
+
+// Down202610192200 drops disabled_at from contact_methods
+func Down202610192200(tx *gorm.DB) error {
+	return tx.Migrator().DropColumn(&models.ContactMethod{}, "disabled_at")
+}
@@ -0,0 +1,30 @@
+package migrate
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	Plan202610192300 = NewMigrationPlan("202610192300", Up202610192300, Down202610192300)
+)
+
+func init() {
+	if !slices.ContainsFunc(plans, func(p *MigrationPlan) bool {
+		return p.ID == Plan202610192300.ID
+	}) {
+		panic("Plan202610192300 is not registered")
+	}
+}
+
+// Up202610192300 adds email to users
+func Up202610192300(tx *gorm.DB) error {
+	return tx.AutoMigrate(&models.User{})
+}
+
+// Down202610192300 drops email from users
+func Down202610192300(tx *gorm.DB) error {
+	return tx.Migrator().DropColumn(&models.User{}, "email")
+}
@@ -7,6 +7,21 @@ import (
 
 var plans = []*MigrationPlan{
 	Plan202412291545,
+	Plan202610190900,
+	Plan202610191000,
+	Plan202610191100,
+	Plan202610191200,
+	Plan202610191300,
+	Plan202610191400,
+	Plan202610191500,
+	Plan202610191600,
+	Plan202610191700,
+	Plan202610191800,
+	Plan202610191900,
+	Plan202610192000,
+	Plan202610192100,
+	Plan202610192200,
+	Plan202610192300,
 }
 
 func NewMigrator(db *gorm.DB) *gormigrate.Gormigrate {
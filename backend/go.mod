@@ -6,12 +6,14 @@ require (
 	github.com/clerk/clerk-sdk-go/v2 v2.3.1
 	github.com/gin-gonic/gin v1.9.1
 	github.com/go-gormigrate/gormigrate/v2 v2.1.4
+	github.com/go-jose/go-jose/v3 v3.0.4
 	github.com/jackc/pgx/v5 v5.6.0
 	github.com/resend/resend-go/v2 v2.21.0
 	github.com/riverqueue/river v0.7.0
 	github.com/riverqueue/river/riverdriver/riverpgxv5 v0.7.0
 	github.com/riverqueue/river/rivertype v0.7.0
 	github.com/sethvargo/go-envconfig v1.3.0
+	github.com/svix/svix-webhooks v1.68.0
 	go.uber.org/fx v1.24.0
 	gorm.io/driver/postgres v1.6.0
 	gorm.io/gorm v1.30.0
@@ -22,7 +24,6 @@ require (
 	github.com/chenzhuoyu/base64x v0.0.0-20221115062448-fe3a3abad311 // indirect
 	github.com/gabriel-vasile/mimetype v1.4.2 // indirect
 	github.com/gin-contrib/sse v0.1.0 // indirect
-	github.com/go-jose/go-jose/v3 v3.0.4 // indirect
 	github.com/go-playground/locales v0.14.1 // indirect
 	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/go-playground/validator/v10 v10.14.0 // indirect
@@ -41,7 +42,6 @@ require (
 	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/pelletier/go-toml/v2 v2.0.8 // indirect
 	github.com/riverqueue/river/riverdriver v0.7.0 // indirect
-	github.com/svix/svix-webhooks v1.68.0 // indirect
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
 	github.com/ugorji/go/codec v1.2.11 // indirect
 	go.uber.org/dig v1.19.0 // indirect
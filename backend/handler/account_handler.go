@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) handleGetDataExports(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	exports, err := h.accountController.GetExports(actor.GetUserIDInt64())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, exports)
+}
+
+func (h *Handler) handleRequestDataExport(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	export, err := h.accountController.RequestExport(actor.GetUserIDInt64())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, export)
+}
+
+func (h *Handler) handleDownloadDataExport(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid export id"})
+		return
+	}
+
+	archive, err := h.accountController.GetExportArchive(actor.GetUserIDInt64(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="uchi-export-%d.zip"`, id))
+	c.Data(http.StatusOK, "application/zip", archive)
+}
+
+func (h *Handler) handleDeleteAccount(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	if err := h.accountController.DeleteAccount(actor.GetUserIDInt64()); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, protocol.DeleteResponse{Message: "account scheduled for deletion"})
+}
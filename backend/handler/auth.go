@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"fmt"
+	"reminder-app/config"
+	"reminder-app/lib/auth"
+	clerkauth "reminder-app/lib/auth/clerk"
+	localauth "reminder-app/lib/auth/local"
+	"reminder-app/lib/auth/pat"
+
+	"gorm.io/gorm"
+)
+
+// NewAuthProvider accepts personal access tokens alongside session tokens from
+// the provider selected in config.
+func NewAuthProvider(cfg *config.Config, db *gorm.DB) (auth.Provider, error) {
+	session, err := newSessionProvider(cfg, db)
+	if err != nil {
+		return nil, err
+	}
+	return pat.New(db, session), nil
+}
+
+// NewUserDeleter removes identities from the provider selected in config.
+func NewUserDeleter(cfg *config.Config, db *gorm.DB) (auth.UserDeleter, error) {
+	return newSessionProvider(cfg, db)
+}
+
+// sessionProvider is implemented by identity providers that own user accounts.
+type sessionProvider interface {
+	auth.Provider
+	auth.UserDeleter
+}
+
+func newSessionProvider(cfg *config.Config, db *gorm.DB) (sessionProvider, error) {
+	switch cfg.Auth.Provider {
+	case "clerk":
+		return clerkauth.New(db, cfg.Clerk.SecretKey), nil
+	case "local":
+		return localauth.New(db, cfg.Auth.LocalJWTSecret, cfg.Auth.LocalJWTIssuer)
+	default:
+		return nil, fmt.Errorf("unknown auth provider: %s", cfg.Auth.Provider)
+	}
+}
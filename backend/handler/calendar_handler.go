@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) handleGetCalendarFeed(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	feed, err := h.calendarController.GetFeed(actor.GetUserIDInt64())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+func (h *Handler) handleRotateCalendarFeed(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	feed, err := h.calendarController.RotateFeed(actor.GetUserIDInt64())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, feed)
+}
+
+func (h *Handler) handleDeleteCalendarFeed(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	if err := h.calendarController.DeleteFeed(actor.GetUserIDInt64()); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protocol.DeleteResponse{Message: "calendar feed deleted"})
+}
+
+func (h *Handler) handleGetCalendar(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	// Render into a buffer so a failure halfway through still returns an error.
+	var buf bytes.Buffer
+	if err := h.calendarController.RenderFeed(token, &buf); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
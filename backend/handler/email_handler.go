@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"net/http"
+	"reminder-app/lib/actor"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) handleGetEmailInbox(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	inbox, err := h.emailController.GetInbox(actor.GetUserIDInt64())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, inbox)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"reminder-app/lib/actor"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// keepAliveInterval is how often an idle stream sends a comment, so proxies
+// don't close it.
+const keepAliveInterval = 30 * time.Second
+
+// handleStreamEvents streams the user's events as server-sent events until
+// they disconnect.
+func (h *Handler) handleStreamEvents(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	messages, unsubscribe := h.events.Subscribe(actor.GetUserIDInt64())
+	defer unsubscribe()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	// Stops nginx from buffering the stream.
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case message := <-messages:
+			c.SSEvent(message.Type, message.Data)
+		case <-keepAlive.C:
+			io.WriteString(w, ": keep-alive\n\n")
+		}
+		return true
+	})
+}
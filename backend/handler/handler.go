@@ -1,16 +1,30 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"reminder-app/config"
+	"reminder-app/controller/accountcontroller"
+	"reminder-app/controller/backupcontroller"
+	"reminder-app/controller/calendarcontroller"
 	"reminder-app/controller/clerkcontroller"
 	"reminder-app/controller/contactmethodcontroller"
+	"reminder-app/controller/emailcontroller"
+	"reminder-app/controller/errs"
+	"reminder-app/controller/householdcontroller"
+	"reminder-app/controller/importcontroller"
+	"reminder-app/controller/invitationcontroller"
 	"reminder-app/controller/protocol"
+	v1 "reminder-app/controller/protocol/v1"
 	"reminder-app/controller/remindercontroller"
+	"reminder-app/controller/smscontroller"
+	"reminder-app/controller/telegramcontroller"
+	"reminder-app/controller/tokencontroller"
 	"reminder-app/lib/actor"
+	"reminder-app/lib/auth"
+	"reminder-app/lib/events"
 	"strconv"
 
-	"github.com/clerk/clerk-sdk-go/v2"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/fx"
 	"gorm.io/gorm"
@@ -20,9 +34,24 @@ type Handler struct {
 	*gin.Engine
 	db                      *gorm.DB
 	config                  *config.Config
+	authProvider            auth.Provider
 	reminderController      *remindercontroller.Controller
 	contactMethodController *contactmethodcontroller.Controller
 	clerkController         *clerkcontroller.Controller
+	tokenController         *tokencontroller.Controller
+	householdController     *householdcontroller.Controller
+	invitationController    *invitationcontroller.Controller
+	calendarController      *calendarcontroller.Controller
+	importController        *importcontroller.Controller
+	backupController        *backupcontroller.Controller
+	accountController       *accountcontroller.Controller
+	telegramController      *telegramcontroller.Controller
+	emailController         *emailcontroller.Controller
+	smsController           *smscontroller.Controller
+	events                  *events.Hub
+
+	// openAPI is the rendered spec served at openapi.json under each version.
+	openAPI []byte
 }
 
 type Params struct {
@@ -30,9 +59,21 @@ type Params struct {
 
 	Config                  *config.Config
 	DB                      *gorm.DB
+	AuthProvider            auth.Provider
 	ReminderController      *remindercontroller.Controller
 	ContactMethodController *contactmethodcontroller.Controller
 	ClerkController         *clerkcontroller.Controller
+	TokenController         *tokencontroller.Controller
+	HouseholdController     *householdcontroller.Controller
+	InvitationController    *invitationcontroller.Controller
+	CalendarController      *calendarcontroller.Controller
+	ImportController        *importcontroller.Controller
+	BackupController        *backupcontroller.Controller
+	AccountController       *accountcontroller.Controller
+	TelegramController      *telegramcontroller.Controller
+	EmailController         *emailcontroller.Controller
+	SMSController           *smscontroller.Controller
+	Events                  *events.Hub
 }
 
 var _ http.Handler = (*Handler)(nil)
@@ -43,36 +84,77 @@ func New(p Params) *Handler {
 		Engine:                  api,
 		db:                      p.DB,
 		config:                  p.Config,
+		authProvider:            p.AuthProvider,
 		reminderController:      p.ReminderController,
 		contactMethodController: p.ContactMethodController,
 		clerkController:         p.ClerkController,
+		tokenController:         p.TokenController,
+		householdController:     p.HouseholdController,
+		invitationController:    p.InvitationController,
+		calendarController:      p.CalendarController,
+		importController:        p.ImportController,
+		backupController:        p.BackupController,
+		accountController:       p.AccountController,
+		telegramController:      p.TelegramController,
+		emailController:         p.EmailController,
+		smsController:           p.SMSController,
+		events:                  p.Events,
 	}
 	return h.init()
 }
 
 func (h *Handler) init() *Handler {
-	clerk.SetKey(h.config.Clerk.SecretKey)
-
 	h.Use(httpOptionsMiddleware())
 
-	api := h.Group("/api")
-	api.Use(clerkAuthMiddleware())
-	api.Use(injectActorMiddleware(h.db))
-	api.GET("/reminders", h.handleGetReminders)
-	api.POST("/reminders", h.handleCreateReminder)
-	api.PUT("/reminders/:id", h.handleUpdateReminder)
-	api.DELETE("/reminders/:id", h.handleDeleteReminder)
-	api.GET("/contact-methods", h.handleGetContactMethods)
-	api.POST("/contact-methods", h.handleCreateContactMethod)
-	api.PUT("/contact-methods/:id", h.handleUpdateContactMethod)
-	api.DELETE("/contact-methods/:id", h.handleDeleteContactMethod)
+	h.openAPI = marshalOpenAPI()
+
+	for _, version := range APIVersions() {
+		api := h.Group(version.Prefix)
+		if version.IsDeprecated() {
+			api.Use(deprecationMiddleware(version))
+		}
+		authenticated := api.Group("", authMiddleware(h.authProvider))
+		for _, route := range version.Routes() {
+			group := authenticated
+			if route.Public {
+				group = api
+			}
+			group.Handle(route.Method, route.Path, route.handlers(h)...)
+		}
+	}
+
+	// Calendar apps can't send headers, so the feed token is the credential.
+	h.GET("/ical/:token", h.handleGetCalendar)
 
 	webhooks := h.Group("/webhooks")
 	webhooks.POST("/clerk", h.handleClerkWebhook)
+	webhooks.POST("/telegram", h.handleTelegramWebhook)
+	webhooks.POST("/resend", h.handleResendWebhook)
+	webhooks.POST("/twilio", h.handleTwilioWebhook)
 
 	return h
 }
 
+// writeError maps controller errors to a status code, defaulting to 500.
+func writeError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errs.ErrInvalid):
+		status = http.StatusBadRequest
+	case errors.Is(err, errs.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, errs.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, errs.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, errs.ErrPreconditionFailed):
+		status = http.StatusPreconditionFailed
+	case errors.Is(err, errs.ErrUnavailable):
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, protocol.ErrorResponse{Error: err.Error()})
+}
+
 func (h *Handler) handleGetReminders(c *gin.Context) {
 	var query protocol.GetRemindersQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
@@ -82,19 +164,17 @@ func (h *Handler) handleGetReminders(c *gin.Context) {
 
 	actor := actor.FromGin(c)
 
-	reminders, err := h.reminderController.GetReminders(actor.GetUserIDInt64(), query.IncludePast)
+	page, err := h.reminderController.GetReminders(actor, &query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, protocol.ErrorResponse{Error: err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, reminders)
+	c.JSON(http.StatusOK, v1.FromReminderPage(*page))
 }
 
 func (h *Handler) handleCreateReminder(c *gin.Context) {
-	// todo: validate that the contact method belongs to the user
-
-	var reminder protocol.CreateReminderRequest
+	var reminder v1.CreateReminderRequest
 	if err := c.ShouldBindJSON(&reminder); err != nil {
 		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
 		return
@@ -102,17 +182,18 @@ func (h *Handler) handleCreateReminder(c *gin.Context) {
 
 	actor := actor.FromGin(c)
 
-	savedReminder, err := h.reminderController.CreateReminder(actor.GetUserIDInt64(), &reminder)
+	req := reminder.Protocol()
+	savedReminder, err := h.reminderController.CreateReminder(actor, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, protocol.ErrorResponse{Error: err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, savedReminder)
+	c.JSON(http.StatusCreated, v1.FromReminder(*savedReminder))
 }
 
 func (h *Handler) handleUpdateReminder(c *gin.Context) {
-	// todo: validate that the reminder belongs to the user
+	actor := actor.FromGin(c)
 
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -121,23 +202,65 @@ func (h *Handler) handleUpdateReminder(c *gin.Context) {
 		return
 	}
 
-	var reminder protocol.UpdateReminderRequest
+	var reminder v1.UpdateReminderRequest
 	if err := c.ShouldBindJSON(&reminder); err != nil {
 		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	updatedReminder, err := h.reminderController.UpdateReminder(id, &reminder)
+	req := reminder.Protocol()
+	updatedReminder, err := h.reminderController.UpdateReminder(actor, id, &req, c.GetHeader("If-Match"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, protocol.ErrorResponse{Error: err.Error()})
+		writeError(c, err)
+		return
+	}
+
+	writeVersioned(c, http.StatusOK, v1.FromReminder(*updatedReminder), updatedReminder.UpdatedAt)
+}
+
+func (h *Handler) handleGetReminder(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid reminder id"})
+		return
+	}
+
+	reminder, err := h.reminderController.GetReminder(actor, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeVersioned(c, http.StatusOK, v1.FromReminder(*reminder), reminder.UpdatedAt)
+}
+
+func (h *Handler) handlePatchReminder(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid reminder id"})
+		return
+	}
+
+	patch, ok := readMergePatch(c)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, updatedReminder)
+	reminder, err := h.reminderController.PatchReminder(actor, id, patch, c.GetHeader("If-Match"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeVersioned(c, http.StatusOK, v1.FromReminder(*reminder), reminder.UpdatedAt)
 }
 
 func (h *Handler) handleDeleteReminder(c *gin.Context) {
-	// todo: validate that the reminder belongs to the user
+	actor := actor.FromGin(c)
 
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -146,14 +269,192 @@ func (h *Handler) handleDeleteReminder(c *gin.Context) {
 		return
 	}
 
-	if err := h.reminderController.DeleteReminder(id); err != nil {
-		c.JSON(http.StatusInternalServerError, protocol.ErrorResponse{Error: err.Error()})
+	if err := h.reminderController.DeleteReminder(actor, id); err != nil {
+		writeError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, protocol.DeleteResponse{Message: "reminder deleted"})
 }
 
+func (h *Handler) handlePauseReminder(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid reminder id"})
+		return
+	}
+
+	// The body is optional: without one the reminder is paused indefinitely.
+	var req protocol.PauseReminderRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	reminder, err := h.reminderController.PauseReminder(actor, id, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.FromReminder(*reminder))
+}
+
+func (h *Handler) handleResumeReminder(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid reminder id"})
+		return
+	}
+
+	reminder, err := h.reminderController.ResumeReminder(actor, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.FromReminder(*reminder))
+}
+
+func (h *Handler) handleBatchReminders(c *gin.Context) {
+	var batch v1.BatchRemindersRequest
+	if err := c.ShouldBindJSON(&batch); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	actor := actor.FromGin(c)
+
+	req := batch.Protocol()
+	response, err := h.reminderController.BatchReminders(actor, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.FromBatchRemindersResponse(*response))
+}
+
+func (h *Handler) handleGetTrash(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	reminders, err := h.reminderController.GetTrash(actor)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.FromReminders(reminders))
+}
+
+func (h *Handler) handleRestoreReminder(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid reminder id"})
+		return
+	}
+
+	reminder, err := h.reminderController.RestoreReminder(actor, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.FromReminder(*reminder))
+}
+
+func (h *Handler) handleSkipRotation(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid reminder id"})
+		return
+	}
+
+	reminder, err := h.reminderController.SkipRotation(actor, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.FromReminder(*reminder))
+}
+
+func (h *Handler) handleSwapRotation(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid reminder id"})
+		return
+	}
+
+	var req protocol.SwapRotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	reminder, err := h.reminderController.SwapRotation(actor, id, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.FromReminder(*reminder))
+}
+
+func (h *Handler) handleGetOccurrences(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid reminder id"})
+		return
+	}
+
+	var query protocol.OccurrencesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	occurrences, err := h.reminderController.GetOccurrences(actor, id, &query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, occurrences)
+}
+
+func (h *Handler) handleGetAgenda(c *gin.Context) {
+	var query protocol.OccurrencesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	actor := actor.FromGin(c)
+
+	occurrences, err := h.reminderController.GetAgenda(actor, &query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, occurrences)
+}
+
 func (h *Handler) handleGetContactMethods(c *gin.Context) {
 	actor := actor.FromGin(c)
 
@@ -163,28 +464,31 @@ func (h *Handler) handleGetContactMethods(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, contactMethods)
+	c.JSON(http.StatusOK, v1.FromContactMethods(contactMethods))
 }
 
 func (h *Handler) handleCreateContactMethod(c *gin.Context) {
 	actor := actor.FromGin(c)
 
-	var contactMethod protocol.CreateContactMethodRequest
+	var contactMethod v1.CreateContactMethodRequest
 	if err := c.ShouldBindJSON(&contactMethod); err != nil {
 		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	savedContactMethod, err := h.contactMethodController.CreateContactMethod(actor.GetUserIDInt64(), &contactMethod)
+	req := contactMethod.Protocol()
+	savedContactMethod, err := h.contactMethodController.CreateContactMethod(actor.GetUserIDInt64(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, protocol.ErrorResponse{Error: err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, savedContactMethod)
+	c.JSON(http.StatusCreated, v1.FromContactMethod(*savedContactMethod))
 }
 
 func (h *Handler) handleUpdateContactMethod(c *gin.Context) {
+	actor := actor.FromGin(c)
+
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
@@ -192,22 +496,66 @@ func (h *Handler) handleUpdateContactMethod(c *gin.Context) {
 		return
 	}
 
-	var contactMethod protocol.UpdateContactMethodRequest
+	var contactMethod v1.UpdateContactMethodRequest
 	if err := c.ShouldBindJSON(&contactMethod); err != nil {
 		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	updatedContactMethod, err := h.contactMethodController.UpdateContactMethod(id, &contactMethod)
+	req := contactMethod.Protocol()
+	updatedContactMethod, err := h.contactMethodController.UpdateContactMethod(actor.GetUserIDInt64(), id, &req, c.GetHeader("If-Match"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, protocol.ErrorResponse{Error: err.Error()})
+		writeError(c, err)
+		return
+	}
+
+	writeVersioned(c, http.StatusOK, v1.FromContactMethod(*updatedContactMethod), updatedContactMethod.UpdatedAt)
+}
+
+func (h *Handler) handleGetContactMethod(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid contact method id"})
 		return
 	}
 
-	c.JSON(http.StatusOK, updatedContactMethod)
+	contactMethod, err := h.contactMethodController.GetContactMethod(actor.GetUserIDInt64(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeVersioned(c, http.StatusOK, v1.FromContactMethod(*contactMethod), contactMethod.UpdatedAt)
+}
+
+func (h *Handler) handlePatchContactMethod(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid contact method id"})
+		return
+	}
+
+	patch, ok := readMergePatch(c)
+	if !ok {
+		return
+	}
+
+	contactMethod, err := h.contactMethodController.PatchContactMethod(actor.GetUserIDInt64(), id, patch, c.GetHeader("If-Match"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeVersioned(c, http.StatusOK, v1.FromContactMethod(*contactMethod), contactMethod.UpdatedAt)
 }
 
 func (h *Handler) handleDeleteContactMethod(c *gin.Context) {
+	actor := actor.FromGin(c)
+
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
@@ -215,8 +563,8 @@ func (h *Handler) handleDeleteContactMethod(c *gin.Context) {
 		return
 	}
 
-	if err := h.contactMethodController.DeleteContactMethod(id); err != nil {
-		c.JSON(http.StatusInternalServerError, protocol.ErrorResponse{Error: err.Error()})
+	if err := h.contactMethodController.DeleteContactMethod(actor.GetUserIDInt64(), id); err != nil {
+		writeError(c, err)
 		return
 	}
 
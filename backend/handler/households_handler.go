@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"net/http"
+	"reminder-app/controller/protocol"
+	v1 "reminder-app/controller/protocol/v1"
+	"reminder-app/lib/actor"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) handleGetHouseholds(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	households, err := h.householdController.GetHouseholds(actor)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, households)
+}
+
+func (h *Handler) handleCreateHousehold(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	var req protocol.CreateHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	household, err := h.householdController.CreateHousehold(actor, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, household)
+}
+
+func (h *Handler) handleUpdateHousehold(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid household id"})
+		return
+	}
+
+	var req protocol.UpdateHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	household, err := h.householdController.UpdateHousehold(actor, id, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+func (h *Handler) handleDeleteHousehold(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid household id"})
+		return
+	}
+
+	if err := h.householdController.DeleteHousehold(actor, id); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protocol.DeleteResponse{Message: "household deleted"})
+}
+
+func (h *Handler) handleGetHouseholdMembers(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid household id"})
+		return
+	}
+
+	members, err := h.householdController.GetMembers(actor, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+func (h *Handler) handleUpdateHouseholdMember(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid household id"})
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("userID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid user id"})
+		return
+	}
+
+	var req protocol.UpdateHouseholdMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	member, err := h.householdController.UpdateMemberRole(actor, id, userID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+func (h *Handler) handleRemoveHouseholdMember(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid household id"})
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("userID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid user id"})
+		return
+	}
+
+	if err := h.householdController.RemoveMember(actor, id, userID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protocol.DeleteResponse{Message: "member removed"})
+}
+
+func (h *Handler) handleGetHouseholdContactMethods(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid household id"})
+		return
+	}
+
+	contactMethods, err := h.contactMethodController.GetHouseholdContactMethods(actor, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.FromContactMethods(contactMethods))
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"reminder-app/controller/backupcontroller"
+	"reminder-app/controller/protocol"
+	v1 "reminder-app/controller/protocol/v1"
+	"reminder-app/lib/actor"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxImportBytes = 5 << 20
+
+// importFile returns the uploaded file from a multipart "file" field, or the
+// raw request body for other content types.
+func importFile(c *gin.Context) (io.ReadCloser, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+	if !strings.HasPrefix(c.ContentType(), "multipart/") {
+		return c.Request.Body, nil
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	return header.Open()
+}
+
+func (h *Handler) handleImportICS(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	var query protocol.ImportICSQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	file, err := importFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer file.Close()
+
+	result, err := h.importController.ImportICS(actor, file, &query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if query.Confirm {
+		status = http.StatusCreated
+	}
+	c.JSON(status, v1.FromImportICSResponse(*result))
+}
+
+func (h *Handler) handleExport(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	var query protocol.ExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := backupcontroller.ValidateExportQuery(&query); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", backupcontroller.ContentType(&query))
+	c.Header("Content-Disposition", `attachment; filename="`+backupcontroller.Filename(&query)+`"`)
+	if err := h.backupController.Export(actor.GetUserIDInt64(), &query, c.Writer); err != nil {
+		// Once streaming has started the status is already sent.
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			writeError(c, err)
+		}
+		return
+	}
+}
+
+func (h *Handler) handleImport(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	var query protocol.ImportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	file, err := importFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer file.Close()
+
+	result, err := h.backupController.Import(actor, file, &query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"net/http"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) handleCreateHouseholdInvitation(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid household id"})
+		return
+	}
+
+	var req protocol.CreateHouseholdInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	invitation, err := h.invitationController.InviteToHousehold(actor, id, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+func (h *Handler) handleGetHouseholdInvitations(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid household id"})
+		return
+	}
+
+	invitations, err := h.invitationController.GetHouseholdInvitations(actor, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (h *Handler) handleShareReminder(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid reminder id"})
+		return
+	}
+
+	var req protocol.ShareReminderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	invitation, err := h.invitationController.ShareReminder(actor, id, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+func (h *Handler) handleUnsubscribeReminder(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid reminder id"})
+		return
+	}
+
+	if err := h.invitationController.Unsubscribe(actor, id); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protocol.DeleteResponse{Message: "unsubscribed"})
+}
+
+func (h *Handler) handleGetInvitations(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	invitations, err := h.invitationController.GetInvitations(actor)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (h *Handler) handleAcceptInvitation(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	var req protocol.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	invitation, err := h.invitationController.AcceptInvitation(actor, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+func (h *Handler) handleRevokeInvitation(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid invitation id"})
+		return
+	}
+
+	if err := h.invitationController.RevokeInvitation(actor, id); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protocol.DeleteResponse{Message: "invitation revoked"})
+}
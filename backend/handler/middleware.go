@@ -4,19 +4,18 @@ import (
 	"net/http"
 	"reminder-app/controller/protocol"
 	"reminder-app/lib/actor"
-	"reminder-app/models"
+	"reminder-app/lib/auth"
+	"strings"
 
-	"github.com/clerk/clerk-sdk-go/v2"
-	clerkhttp "github.com/clerk/clerk-sdk-go/v2/http"
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 func httpOptionsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match")
+		c.Header("Access-Control-Expose-Headers", "ETag, Deprecation, Sunset, Link")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -27,51 +26,47 @@ func httpOptionsMiddleware() gin.HandlerFunc {
 	}
 }
 
-func clerkAuthMiddleware() gin.HandlerFunc {
+func authMiddleware(provider auth.Provider) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
 			c.JSON(http.StatusUnauthorized, protocol.ErrorResponse{Error: "authorization header required"})
 			c.Abort()
 			return
 		}
 
-		// AI generated.... it works but I don't fully understand it. Come back to this to see if we can simplify it.
-		wrappedHandler := clerkhttp.WithHeaderAuthorization()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			claims, ok := clerk.SessionClaimsFromContext(r.Context())
-			if !ok {
-				c.JSON(http.StatusUnauthorized, protocol.ErrorResponse{Error: "invalid or expired token"})
-				c.Abort()
-				return
-			}
-			c.Set("clerkID", claims.Subject)
-		}))
-
-		wrappedHandler.ServeHTTP(c.Writer, c.Request)
-
-		if _, exists := c.Get("clerkID"); exists {
-			c.Next()
+		user, err := provider.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, protocol.ErrorResponse{Error: "unauthorized"})
+			c.Abort()
+			return
 		}
+
+		c.Set("user", user)
+		c.Next()
 	}
 }
 
-func injectActorMiddleware(db *gorm.DB) gin.HandlerFunc {
+// requireScope rejects personal access tokens that were not granted scope.
+func requireScope(scope string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		clerkID, exists := c.Get("clerkID")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, protocol.ErrorResponse{Error: "unauthorized"})
+		if !actor.FromGin(c).HasScope(scope) {
+			c.JSON(http.StatusForbidden, protocol.ErrorResponse{Error: "token is missing scope " + scope})
 			c.Abort()
 			return
 		}
+		c.Next()
+	}
+}
 
-		user := &models.User{}
-		if err := db.First(user, "clerk_id = ?", clerkID).Error; err != nil {
-			c.JSON(http.StatusUnauthorized, protocol.ErrorResponse{Error: "unauthorized"})
+// requireSession rejects personal access tokens, e.g. so a token can't mint more tokens.
+func requireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if actor.FromGin(c).IsToken() {
+			c.JSON(http.StatusForbidden, protocol.ErrorResponse{Error: "this endpoint requires a session"})
 			c.Abort()
 			return
 		}
-
-		c.Set("user", actor.New(user.ID, user.ClerkID))
 		c.Next()
 	}
 }
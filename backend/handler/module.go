@@ -6,4 +6,6 @@ import (
 
 var Module = fx.Module("handler",
 	fx.Provide(New),
+	fx.Provide(NewAuthProvider),
+	fx.Provide(NewUserDeleter),
 )
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/openapi"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const bearerScheme = "bearer"
+
+// OpenAPI describes the routes of every version in APIVersions.
+func OpenAPI() *openapi.Document {
+	schemas := openapi.NewSchemas()
+	errorSchema := schemas.For(protocol.ErrorResponse{})
+
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title:   "Reminders API",
+			Version: "1",
+			Description: "Personal access tokens are limited to the scopes listed on each operation, " +
+				"and operations marked x-session-only reject them.",
+		},
+		Paths: map[string]*openapi.PathItem{},
+		Components: openapi.Components{
+			SecuritySchemes: map[string]openapi.SecurityScheme{
+				bearerScheme: {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "A session token, or a personal access token from /api/v1/tokens.",
+				},
+			},
+		},
+	}
+
+	for _, version := range APIVersions() {
+		for _, route := range version.Routes() {
+			path := OpenAPIPath(version.Prefix + route.Path)
+			item, ok := doc.Paths[path]
+			if !ok {
+				item = &openapi.PathItem{}
+				doc.Paths[path] = item
+			}
+			op := route.operation(schemas, errorSchema)
+			op.OperationID += version.OperationSuffix
+			if version.IsDeprecated() {
+				op.Deprecated = true
+				op.Description = fmt.Sprintf("Use %s instead. This path stops working after %s.",
+					OpenAPIPath(version.Successor+route.Path), version.Sunset.Format(time.DateOnly))
+			}
+			(*item)[strings.ToLower(route.Method)] = op
+		}
+	}
+
+	doc.Components.Schemas = schemas.Components()
+	return doc
+}
+
+// OpenAPIPath converts gin's :param segments to OpenAPI's {param}.
+func OpenAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func (r Route) operation(schemas *openapi.Schemas, errorSchema *openapi.Schema) *openapi.Operation {
+	op := &openapi.Operation{
+		OperationID: r.Name,
+		Summary:     r.Summary,
+		Tags:        []string{r.Tag},
+		Responses:   map[string]*openapi.Response{},
+		SessionOnly: r.Session,
+	}
+	if !r.Public {
+		// Session-only routes need no scopes, but the list must still be [].
+		scopes := append([]string{}, r.Scopes...)
+		op.Security = []openapi.SecurityRequirement{{bearerScheme: scopes}}
+	}
+
+	for _, name := range r.PathParams() {
+		op.Parameters = append(op.Parameters, openapi.Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &openapi.Schema{Type: "integer", Format: "int64"},
+		})
+	}
+	if r.Query != nil {
+		op.Parameters = append(op.Parameters, schemas.QueryParameters(r.Query)...)
+	}
+	if r.ChecksETag() {
+		op.Parameters = append(op.Parameters, openapi.Parameter{
+			Name:        "If-Match",
+			In:          "header",
+			Description: "The ETag of the version being changed.",
+			Schema:      &openapi.Schema{Type: "string"},
+		})
+	}
+
+	if r.Body != nil || r.Uploads != nil {
+		body := &openapi.RequestBody{Required: !r.OptionalBody, Content: map[string]openapi.MediaType{}}
+		if r.Body != nil {
+			bodyType := r.BodyType
+			if bodyType == "" {
+				bodyType = "application/json"
+			}
+			body.Content[bodyType] = openapi.MediaType{Schema: schemas.For(r.Body)}
+		}
+		for _, contentType := range r.Uploads {
+			body.Content[contentType] = openapi.MediaType{Schema: uploadSchema(contentType)}
+		}
+		op.RequestBody = body
+	}
+
+	success := &openapi.Response{Description: http.StatusText(r.Status)}
+	if r.Response != nil || r.Downloads != nil || r.Events != nil {
+		success.Content = map[string]openapi.MediaType{}
+	}
+	if r.Response != nil {
+		success.Content["application/json"] = openapi.MediaType{Schema: schemas.For(r.Response)}
+	}
+	for _, contentType := range r.Downloads {
+		success.Content[contentType] = openapi.MediaType{Schema: &openapi.Schema{ContentMediaType: contentType}}
+	}
+	if r.Events != nil {
+		success.Content["text/event-stream"] = openapi.MediaType{
+			Schema: &openapi.Schema{Type: "string", ContentMediaType: "text/event-stream"},
+			Events: schemas.For(r.Events),
+		}
+	}
+	if r.ETag {
+		success.Headers = map[string]openapi.Header{
+			"ETag": {Description: "Send back in If-Match to update this version.", Schema: &openapi.Schema{Type: "string"}},
+		}
+	}
+	op.Responses[strconv.Itoa(r.Status)] = success
+
+	for _, status := range r.ErrorStatuses() {
+		op.Responses[strconv.Itoa(status)] = &openapi.Response{
+			Description: http.StatusText(status),
+			Content:     map[string]openapi.MediaType{"application/json": {Schema: errorSchema}},
+		}
+	}
+	return op
+}
+
+// PathParams returns the names of the route's path parameters, in order.
+func (r Route) PathParams() []string {
+	var names []string
+	for _, segment := range strings.Split(r.Path, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ErrorStatuses returns every error status the route can respond with short
+// of a 500, in ascending order.
+func (r Route) ErrorStatuses() []int {
+	statuses := slices.Clone(r.Errors)
+	if !r.Public {
+		statuses = append(statuses, http.StatusUnauthorized, http.StatusForbidden)
+	}
+	if r.PathParams() != nil {
+		statuses = append(statuses, http.StatusBadRequest, http.StatusNotFound)
+	}
+	if r.Query != nil || r.Body != nil || r.Uploads != nil {
+		statuses = append(statuses, http.StatusBadRequest)
+	}
+	if r.ChecksETag() {
+		statuses = append(statuses, http.StatusPreconditionFailed)
+	}
+	slices.Sort(statuses)
+	return slices.Compact(statuses)
+}
+
+// ChecksETag reports whether the route honours If-Match.
+func (r Route) ChecksETag() bool {
+	return r.ETag && (r.Method == http.MethodPut || r.Method == http.MethodPatch)
+}
+
+// uploadSchema describes a file sent raw, or as the "file" field of a form.
+func uploadSchema(contentType string) *openapi.Schema {
+	if contentType != "multipart/form-data" {
+		return &openapi.Schema{ContentMediaType: contentType}
+	}
+	return &openapi.Schema{
+		Type:       "object",
+		Properties: map[string]*openapi.Schema{"file": {ContentMediaType: "application/octet-stream"}},
+		Required:   []string{"file"},
+	}
+}
+
+func (h *Handler) handleGetOpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.openAPI)
+}
+
+// marshalOpenAPI renders the document once, since the routes never change
+// while the server runs.
+func marshalOpenAPI() []byte {
+	spec, err := json.Marshal(OpenAPI())
+	if err != nil {
+		// The document is built from plain structs, so this can't happen.
+		panic(err)
+	}
+	return spec
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reminder-app/lib/openapi"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestOpenAPIMatchesRoutes fails when a route is registered under /api
+// without being in the spec, or the spec documents a route that isn't served.
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := (&Handler{Engine: gin.New()}).init()
+
+	var registered []string
+	for _, route := range h.Routes() {
+		if strings.HasPrefix(route.Path, "/api/") {
+			registered = append(registered, route.Method+" "+OpenAPIPath(route.Path))
+		}
+	}
+
+	var documented []string
+	for path, item := range OpenAPI().Paths {
+		for method := range *item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	for _, route := range registered {
+		if !slices.Contains(documented, route) {
+			t.Errorf("%s is registered but missing from the spec", route)
+		}
+	}
+	for _, route := range documented {
+		if !slices.Contains(registered, route) {
+			t.Errorf("%s is in the spec but not registered", route)
+		}
+	}
+}
+
+func TestOpenAPIOperations(t *testing.T) {
+	doc := OpenAPI()
+
+	names := map[string]string{}
+	for path, item := range doc.Paths {
+		for method, op := range *item {
+			if other, ok := names[op.OperationID]; ok {
+				t.Errorf("%s %s reuses operation ID %s of %s", method, path, op.OperationID, other)
+			}
+			names[op.OperationID] = method + " " + path
+
+			for _, param := range op.Parameters {
+				if param.In == "path" && !strings.Contains(path, "{"+param.Name+"}") {
+					t.Errorf("%s %s has parameter %s that isn't in its path", method, path, param.Name)
+				}
+			}
+		}
+	}
+
+	// Every $ref must point at a component.
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var refs []string
+	collectRefs(t, spec, &refs)
+	for _, ref := range refs {
+		name := (&openapi.Schema{Ref: ref}).RefName()
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("%s doesn't resolve", ref)
+		}
+	}
+}
+
+func TestServeOpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := (&Handler{Engine: gin.New()}).init()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d without authentication, want 200", w.Code)
+	}
+
+	var doc openapi.Document
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != openapi.Version {
+		t.Errorf("got openapi %q, want %q", doc.OpenAPI, openapi.Version)
+	}
+}
+
+func collectRefs(t *testing.T, spec []byte, refs *[]string) {
+	t.Helper()
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for key, value := range v {
+				if ref, ok := value.(string); ok && key == "$ref" {
+					*refs = append(*refs, ref)
+					continue
+				}
+				walk(value)
+			}
+		case []any:
+			for _, value := range v {
+				walk(value)
+			}
+		}
+	}
+	var doc any
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		t.Fatal(err)
+	}
+	walk(doc)
+}
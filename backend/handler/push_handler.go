@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+	"reminder-app/controller/protocol"
+	v1 "reminder-app/controller/protocol/v1"
+	"reminder-app/lib/actor"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) handleGetPushPublicKey(c *gin.Context) {
+	key, err := h.contactMethodController.GetPushPublicKey()
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+func (h *Handler) handleCreatePushSubscription(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	var req protocol.CreatePushSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	contactMethod, err := h.contactMethodController.CreatePushSubscription(actor.GetUserIDInt64(), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, v1.FromContactMethod(*contactMethod))
+}
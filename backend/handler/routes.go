@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Route is an endpoint of a version of the API. init registers the routes of
+// every version in APIVersions, and the OpenAPI document and generated client
+// are built from the same tables.
+type Route struct {
+	Method string
+	// Path is relative to the version's prefix, with gin's :param syntax.
+	Path string
+	// Name is the operation ID and the generated client's method name.
+	Name    string
+	Summary string
+	Tag     string
+
+	// Scopes are required of personal access tokens, Session rejects tokens
+	// altogether and Public skips authentication.
+	Scopes  []string
+	Session bool
+	Public  bool
+
+	// Query is the struct bound from the query string.
+	Query any
+	// Body is the JSON request body, sent as BodyType if that isn't
+	// application/json. OptionalBody allows an empty request.
+	Body         any
+	BodyType     string
+	OptionalBody bool
+	// Uploads are content types accepted as a raw file, multipart/form-data
+	// with a "file" field included.
+	Uploads []string
+
+	// Status is the success status, with a JSON Response body and Downloads
+	// for other content types it may be sent as.
+	Status    int
+	Response  any
+	Downloads []string
+	// Events is the data of each server-sent event in a text/event-stream
+	// response.
+	Events any
+	// ETag marks responses that carry an ETag, and requests that honour
+	// If-Match.
+	ETag bool
+	// Errors lists error statuses beyond those the rest of the route implies:
+	// 400 for input, 404 for path parameters, and 401 and 403 for auth.
+	Errors []int
+
+	handle func(*Handler, *gin.Context)
+}
+
+// handlers returns the middleware enforcing the route's auth followed by its
+// handler.
+func (r Route) handlers(h *Handler) []gin.HandlerFunc {
+	var handlers []gin.HandlerFunc
+	if r.Session {
+		handlers = append(handlers, requireSession())
+	}
+	for _, scope := range r.Scopes {
+		handlers = append(handlers, requireScope(scope))
+	}
+	return append(handlers, func(c *gin.Context) {
+		r.handle(h, c)
+	})
+}
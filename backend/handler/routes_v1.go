@@ -0,0 +1,418 @@
+package handler
+
+import (
+	"net/http"
+	v1 "reminder-app/controller/protocol/v1"
+	"reminder-app/lib/auth"
+)
+
+// V1Routes returns the endpoints of /api/v1. It is a function rather than a
+// variable because the spec handler refers back to it.
+func V1Routes() []Route {
+	remindersRead := []string{auth.ScopeRemindersRead}
+	remindersWrite := []string{auth.ScopeRemindersWrite}
+	contactMethodsRead := []string{auth.ScopeContactMethodsRead}
+	contactMethodsWrite := []string{auth.ScopeContactMethodsWrite}
+
+	return []Route{
+		{
+			Method: http.MethodGet, Path: "/reminders", Name: "getReminders", Tag: "reminders",
+			Summary: "List reminders, a page at a time",
+			Scopes:  remindersRead, Query: v1.GetRemindersQuery{},
+			Status: http.StatusOK, Response: v1.ReminderPage{},
+			handle: (*Handler).handleGetReminders,
+		},
+		{
+			Method: http.MethodPost, Path: "/reminders", Name: "createReminder", Tag: "reminders",
+			Summary: "Create a reminder",
+			Scopes:  remindersWrite, Body: v1.CreateReminderRequest{},
+			Status: http.StatusCreated, Response: v1.Reminder{},
+			handle: (*Handler).handleCreateReminder,
+		},
+		{
+			Method: http.MethodPost, Path: "/reminders/batch", Name: "batchReminders", Tag: "reminders",
+			Summary: "Apply several reminder operations in one transaction",
+			Scopes:  remindersWrite, Body: v1.BatchRemindersRequest{},
+			Status: http.StatusOK, Response: v1.BatchRemindersResponse{},
+			Errors: []int{http.StatusConflict},
+			handle: (*Handler).handleBatchReminders,
+		},
+		{
+			Method: http.MethodGet, Path: "/reminders/:id", Name: "getReminder", Tag: "reminders",
+			Summary: "Get a reminder",
+			Scopes:  remindersRead,
+			Status:  http.StatusOK, Response: v1.Reminder{}, ETag: true,
+			handle: (*Handler).handleGetReminder,
+		},
+		{
+			Method: http.MethodPut, Path: "/reminders/:id", Name: "updateReminder", Tag: "reminders",
+			Summary: "Replace a reminder",
+			Scopes:  remindersWrite, Body: v1.UpdateReminderRequest{},
+			Status: http.StatusOK, Response: v1.Reminder{}, ETag: true,
+			handle: (*Handler).handleUpdateReminder,
+		},
+		{
+			Method: http.MethodPatch, Path: "/reminders/:id", Name: "patchReminder", Tag: "reminders",
+			Summary: "Change some fields of a reminder with a JSON Merge Patch",
+			Scopes:  remindersWrite, Body: map[string]any{}, BodyType: "application/merge-patch+json",
+			Status: http.StatusOK, Response: v1.Reminder{}, ETag: true,
+			Errors: []int{http.StatusUnsupportedMediaType},
+			handle: (*Handler).handlePatchReminder,
+		},
+		{
+			Method: http.MethodDelete, Path: "/reminders/:id", Name: "deleteReminder", Tag: "reminders",
+			Summary: "Move a reminder to the trash",
+			Scopes:  remindersWrite,
+			Status:  http.StatusOK, Response: v1.DeleteResponse{},
+			handle: (*Handler).handleDeleteReminder,
+		},
+		{
+			Method: http.MethodGet, Path: "/reminders/trash", Name: "getTrash", Tag: "reminders",
+			Summary: "List reminders in the trash",
+			Scopes:  remindersRead,
+			Status:  http.StatusOK, Response: []v1.Reminder{},
+			handle: (*Handler).handleGetTrash,
+		},
+		{
+			Method: http.MethodPost, Path: "/reminders/:id/restore", Name: "restoreReminder", Tag: "reminders",
+			Summary: "Restore a reminder from the trash",
+			Scopes:  remindersWrite,
+			Status:  http.StatusOK, Response: v1.Reminder{},
+			handle: (*Handler).handleRestoreReminder,
+		},
+		{
+			Method: http.MethodPost, Path: "/reminders/:id/pause", Name: "pauseReminder", Tag: "reminders",
+			Summary: "Pause a reminder until a time, or indefinitely",
+			Scopes:  remindersWrite, Body: v1.PauseReminderRequest{}, OptionalBody: true,
+			Status: http.StatusOK, Response: v1.Reminder{},
+			handle: (*Handler).handlePauseReminder,
+		},
+		{
+			Method: http.MethodPost, Path: "/reminders/:id/resume", Name: "resumeReminder", Tag: "reminders",
+			Summary: "Resume a paused reminder",
+			Scopes:  remindersWrite,
+			Status:  http.StatusOK, Response: v1.Reminder{},
+			handle: (*Handler).handleResumeReminder,
+		},
+		{
+			Method: http.MethodGet, Path: "/reminders/:id/occurrences", Name: "getOccurrences", Tag: "reminders",
+			Summary: "List upcoming occurrences of a reminder",
+			Scopes:  remindersRead, Query: v1.OccurrencesQuery{},
+			Status: http.StatusOK, Response: []v1.Occurrence{},
+			handle: (*Handler).handleGetOccurrences,
+		},
+		{
+			Method: http.MethodPost, Path: "/reminders/:id/rotation/skip", Name: "skipRotation", Tag: "reminders",
+			Summary: "Hand a rotating reminder to the next assignee",
+			Scopes:  remindersWrite,
+			Status:  http.StatusOK, Response: v1.Reminder{},
+			handle: (*Handler).handleSkipRotation,
+		},
+		{
+			Method: http.MethodPost, Path: "/reminders/:id/rotation/swap", Name: "swapRotation", Tag: "reminders",
+			Summary: "Swap two positions in a reminder's rotation",
+			Scopes:  remindersWrite, Body: v1.SwapRotationRequest{},
+			Status: http.StatusOK, Response: v1.Reminder{},
+			handle: (*Handler).handleSwapRotation,
+		},
+		{
+			Method: http.MethodPost, Path: "/reminders/:id/shares", Name: "shareReminder", Tag: "invitations",
+			Summary: "Invite someone to receive a reminder",
+			Session: true, Body: v1.ShareReminderRequest{},
+			Status: http.StatusCreated, Response: v1.Invitation{},
+			handle: (*Handler).handleShareReminder,
+		},
+		{
+			Method: http.MethodDelete, Path: "/reminders/:id/subscription", Name: "unsubscribeReminder", Tag: "invitations",
+			Summary: "Stop receiving a reminder shared with you",
+			Session: true,
+			Status:  http.StatusOK, Response: v1.DeleteResponse{},
+			handle: (*Handler).handleUnsubscribeReminder,
+		},
+		{
+			Method: http.MethodPost, Path: "/import/ics", Name: "importICS", Tag: "import",
+			Summary: "Preview or import reminders from an iCalendar file",
+			Scopes:  remindersWrite, Query: v1.ImportICSQuery{}, Uploads: []string{"text/calendar", "multipart/form-data"},
+			Status: http.StatusOK, Response: v1.ImportICSResponse{},
+			handle: (*Handler).handleImportICS,
+		},
+		{
+			Method: http.MethodGet, Path: "/export", Name: "exportBackup", Tag: "import",
+			Summary: "Export contact methods and reminders as JSON or CSV",
+			Scopes:  []string{auth.ScopeRemindersRead, auth.ScopeContactMethodsRead}, Query: v1.ExportQuery{},
+			Status: http.StatusOK, Response: v1.Backup{}, Downloads: []string{"text/csv"},
+			handle: (*Handler).handleExport,
+		},
+		{
+			Method: http.MethodPost, Path: "/import", Name: "importBackup", Tag: "import",
+			Summary: "Import contact methods and reminders from an export",
+			Scopes:  []string{auth.ScopeRemindersWrite, auth.ScopeContactMethodsWrite}, Query: v1.ImportQuery{},
+			Body: v1.Backup{}, Uploads: []string{"text/csv", "multipart/form-data"},
+			Status: http.StatusOK, Response: v1.ImportResult{},
+			handle: (*Handler).handleImport,
+		},
+		{
+			Method: http.MethodGet, Path: "/agenda", Name: "getAgenda", Tag: "reminders",
+			Summary: "List upcoming occurrences of every reminder",
+			Scopes:  remindersRead, Query: v1.OccurrencesQuery{},
+			Status: http.StatusOK, Response: []v1.Occurrence{},
+			handle: (*Handler).handleGetAgenda,
+		},
+		{
+			Method: http.MethodGet, Path: "/contact-methods", Name: "getContactMethods", Tag: "contact-methods",
+			Summary: "List contact methods",
+			Scopes:  contactMethodsRead,
+			Status:  http.StatusOK, Response: []v1.ContactMethod{},
+			handle: (*Handler).handleGetContactMethods,
+		},
+		{
+			Method: http.MethodPost, Path: "/contact-methods", Name: "createContactMethod", Tag: "contact-methods",
+			Summary: "Add a contact method",
+			Scopes:  contactMethodsWrite, Body: v1.CreateContactMethodRequest{},
+			Status: http.StatusCreated, Response: v1.ContactMethod{},
+			handle: (*Handler).handleCreateContactMethod,
+		},
+		{
+			Method: http.MethodGet, Path: "/contact-methods/:id", Name: "getContactMethod", Tag: "contact-methods",
+			Summary: "Get a contact method",
+			Scopes:  contactMethodsRead,
+			Status:  http.StatusOK, Response: v1.ContactMethod{}, ETag: true,
+			handle: (*Handler).handleGetContactMethod,
+		},
+		{
+			Method: http.MethodPut, Path: "/contact-methods/:id", Name: "updateContactMethod", Tag: "contact-methods",
+			Summary: "Replace a contact method",
+			Scopes:  contactMethodsWrite, Body: v1.UpdateContactMethodRequest{},
+			Status: http.StatusOK, Response: v1.ContactMethod{}, ETag: true,
+			handle: (*Handler).handleUpdateContactMethod,
+		},
+		{
+			Method: http.MethodPatch, Path: "/contact-methods/:id", Name: "patchContactMethod", Tag: "contact-methods",
+			Summary: "Change some fields of a contact method with a JSON Merge Patch",
+			Scopes:  contactMethodsWrite, Body: map[string]any{}, BodyType: "application/merge-patch+json",
+			Status: http.StatusOK, Response: v1.ContactMethod{}, ETag: true,
+			Errors: []int{http.StatusUnsupportedMediaType},
+			handle: (*Handler).handlePatchContactMethod,
+		},
+		{
+			Method: http.MethodDelete, Path: "/contact-methods/:id", Name: "deleteContactMethod", Tag: "contact-methods",
+			Summary: "Delete a contact method",
+			Scopes:  contactMethodsWrite,
+			Status:  http.StatusOK, Response: v1.DeleteResponse{},
+			handle: (*Handler).handleDeleteContactMethod,
+		},
+		{
+			Method: http.MethodGet, Path: "/push-subscriptions/public-key", Name: "getPushPublicKey", Tag: "contact-methods",
+			Summary: "Get the key browsers subscribe to push notifications with",
+			Scopes:  contactMethodsRead,
+			Status:  http.StatusOK, Response: v1.PushPublicKey{},
+			Errors: []int{http.StatusServiceUnavailable},
+			handle: (*Handler).handleGetPushPublicKey,
+		},
+		{
+			Method: http.MethodPost, Path: "/push-subscriptions", Name: "createPushSubscription", Tag: "contact-methods",
+			Summary: "Save a browser's push subscription as a push contact method",
+			Scopes:  contactMethodsWrite, Body: v1.CreatePushSubscriptionRequest{},
+			Status: http.StatusCreated, Response: v1.ContactMethod{},
+			Errors: []int{http.StatusServiceUnavailable},
+			handle: (*Handler).handleCreatePushSubscription,
+		},
+		{
+			Method: http.MethodPost, Path: "/telegram/link", Name: "createTelegramLink", Tag: "contact-methods",
+			Summary: "Get a link that connects a Telegram chat as a contact method",
+			Scopes:  contactMethodsWrite,
+			Status:  http.StatusCreated, Response: v1.TelegramLink{},
+			Errors: []int{http.StatusServiceUnavailable},
+			handle: (*Handler).handleCreateTelegramLink,
+		},
+		{
+			Method: http.MethodGet, Path: "/email-inbox", Name: "getEmailInbox", Tag: "reminders",
+			Summary: "Get the address you can email new reminders to",
+			Scopes:  remindersWrite,
+			Status:  http.StatusOK, Response: v1.EmailInbox{},
+			Errors: []int{http.StatusServiceUnavailable},
+			handle: (*Handler).handleGetEmailInbox,
+		},
+		{
+			Method: http.MethodGet, Path: "/households", Name: "getHouseholds", Tag: "households",
+			Summary: "List your households",
+			Session: true,
+			Status:  http.StatusOK, Response: []v1.Household{},
+			handle: (*Handler).handleGetHouseholds,
+		},
+		{
+			Method: http.MethodPost, Path: "/households", Name: "createHousehold", Tag: "households",
+			Summary: "Create a household",
+			Session: true, Body: v1.CreateHouseholdRequest{},
+			Status: http.StatusCreated, Response: v1.Household{},
+			handle: (*Handler).handleCreateHousehold,
+		},
+		{
+			Method: http.MethodPut, Path: "/households/:id", Name: "updateHousehold", Tag: "households",
+			Summary: "Rename a household",
+			Session: true, Body: v1.UpdateHouseholdRequest{},
+			Status: http.StatusOK, Response: v1.Household{},
+			handle: (*Handler).handleUpdateHousehold,
+		},
+		{
+			Method: http.MethodDelete, Path: "/households/:id", Name: "deleteHousehold", Tag: "households",
+			Summary: "Delete a household without reminders",
+			Session: true,
+			Status:  http.StatusOK, Response: v1.DeleteResponse{},
+			Errors: []int{http.StatusConflict},
+			handle: (*Handler).handleDeleteHousehold,
+		},
+		{
+			Method: http.MethodGet, Path: "/households/:id/members", Name: "getHouseholdMembers", Tag: "households",
+			Summary: "List a household's members",
+			Session: true,
+			Status:  http.StatusOK, Response: []v1.HouseholdMember{},
+			handle: (*Handler).handleGetHouseholdMembers,
+		},
+		{
+			Method: http.MethodPut, Path: "/households/:id/members/:userID", Name: "updateHouseholdMember", Tag: "households",
+			Summary: "Change a member's role",
+			Session: true, Body: v1.UpdateHouseholdMemberRequest{},
+			Status: http.StatusOK, Response: v1.HouseholdMember{},
+			Errors: []int{http.StatusConflict},
+			handle: (*Handler).handleUpdateHouseholdMember,
+		},
+		{
+			Method: http.MethodDelete, Path: "/households/:id/members/:userID", Name: "removeHouseholdMember", Tag: "households",
+			Summary: "Remove a member, or leave a household",
+			Session: true,
+			Status:  http.StatusOK, Response: v1.DeleteResponse{},
+			Errors: []int{http.StatusConflict},
+			handle: (*Handler).handleRemoveHouseholdMember,
+		},
+		{
+			Method: http.MethodGet, Path: "/households/:id/contact-methods", Name: "getHouseholdContactMethods", Tag: "households",
+			Summary: "List the contact methods of a household's members",
+			Scopes:  contactMethodsRead,
+			Status:  http.StatusOK, Response: []v1.ContactMethod{},
+			handle: (*Handler).handleGetHouseholdContactMethods,
+		},
+		{
+			Method: http.MethodGet, Path: "/households/:id/invitations", Name: "getHouseholdInvitations", Tag: "invitations",
+			Summary: "List a household's pending invitations",
+			Session: true,
+			Status:  http.StatusOK, Response: []v1.Invitation{},
+			handle: (*Handler).handleGetHouseholdInvitations,
+		},
+		{
+			Method: http.MethodPost, Path: "/households/:id/invitations", Name: "createHouseholdInvitation", Tag: "invitations",
+			Summary: "Invite someone to a household",
+			Session: true, Body: v1.CreateHouseholdInvitationRequest{},
+			Status: http.StatusCreated, Response: v1.Invitation{},
+			handle: (*Handler).handleCreateHouseholdInvitation,
+		},
+		{
+			Method: http.MethodGet, Path: "/invitations", Name: "getInvitations", Tag: "invitations",
+			Summary: "List pending invitations addressed to you",
+			Session: true,
+			Status:  http.StatusOK, Response: []v1.Invitation{},
+			handle: (*Handler).handleGetInvitations,
+		},
+		{
+			Method: http.MethodPost, Path: "/invitations/accept", Name: "acceptInvitation", Tag: "invitations",
+			Summary: "Accept an invitation",
+			Session: true, Body: v1.AcceptInvitationRequest{},
+			Status: http.StatusOK, Response: v1.Invitation{},
+			Errors: []int{http.StatusNotFound, http.StatusConflict},
+			handle: (*Handler).handleAcceptInvitation,
+		},
+		{
+			Method: http.MethodDelete, Path: "/invitations/:id", Name: "revokeInvitation", Tag: "invitations",
+			Summary: "Revoke an invitation",
+			Session: true,
+			Status:  http.StatusOK, Response: v1.DeleteResponse{},
+			handle: (*Handler).handleRevokeInvitation,
+		},
+		{
+			Method: http.MethodGet, Path: "/tokens", Name: "getTokens", Tag: "tokens",
+			Summary: "List personal access tokens",
+			Session: true,
+			Status:  http.StatusOK, Response: []v1.APIToken{},
+			handle: (*Handler).handleGetTokens,
+		},
+		{
+			Method: http.MethodPost, Path: "/tokens", Name: "createToken", Tag: "tokens",
+			Summary: "Create a personal access token",
+			Session: true, Body: v1.CreateAPITokenRequest{},
+			Status: http.StatusCreated, Response: v1.CreateAPITokenResponse{},
+			handle: (*Handler).handleCreateToken,
+		},
+		{
+			Method: http.MethodDelete, Path: "/tokens/:id", Name: "deleteToken", Tag: "tokens",
+			Summary: "Revoke a personal access token",
+			Session: true,
+			Status:  http.StatusOK, Response: v1.DeleteResponse{},
+			handle: (*Handler).handleDeleteToken,
+		},
+		{
+			Method: http.MethodGet, Path: "/calendar-feed", Name: "getCalendarFeed", Tag: "calendar",
+			Summary: "Get your calendar feed",
+			Session: true,
+			Status:  http.StatusOK, Response: v1.CalendarFeed{},
+			Errors: []int{http.StatusNotFound},
+			handle: (*Handler).handleGetCalendarFeed,
+		},
+		{
+			Method: http.MethodPost, Path: "/calendar-feed", Name: "rotateCalendarFeed", Tag: "calendar",
+			Summary: "Create or rotate your calendar feed URL",
+			Session: true,
+			Status:  http.StatusCreated, Response: v1.CalendarFeed{},
+			handle: (*Handler).handleRotateCalendarFeed,
+		},
+		{
+			Method: http.MethodDelete, Path: "/calendar-feed", Name: "deleteCalendarFeed", Tag: "calendar",
+			Summary: "Delete your calendar feed",
+			Session: true,
+			Status:  http.StatusOK, Response: v1.DeleteResponse{},
+			Errors: []int{http.StatusNotFound},
+			handle: (*Handler).handleDeleteCalendarFeed,
+		},
+		{
+			Method: http.MethodGet, Path: "/account/exports", Name: "getDataExports", Tag: "account",
+			Summary: "List your data exports",
+			Session: true,
+			Status:  http.StatusOK, Response: []v1.DataExport{},
+			handle: (*Handler).handleGetDataExports,
+		},
+		{
+			Method: http.MethodPost, Path: "/account/exports", Name: "requestDataExport", Tag: "account",
+			Summary: "Start an export of all your data",
+			Session: true,
+			Status:  http.StatusAccepted, Response: v1.DataExport{},
+			handle: (*Handler).handleRequestDataExport,
+		},
+		{
+			Method: http.MethodGet, Path: "/account/exports/:id/download", Name: "downloadDataExport", Tag: "account",
+			Summary: "Download a finished data export",
+			Session: true,
+			Status:  http.StatusOK, Downloads: []string{"application/zip"},
+			handle: (*Handler).handleDownloadDataExport,
+		},
+		{
+			Method: http.MethodDelete, Path: "/account", Name: "deleteAccount", Tag: "account",
+			Summary: "Delete your account and everything in it",
+			Session: true,
+			Status:  http.StatusAccepted, Response: v1.DeleteResponse{},
+			handle: (*Handler).handleDeleteAccount,
+		},
+		{
+			Method: http.MethodGet, Path: "/events", Name: "streamEvents", Tag: "events",
+			Summary: "Stream changes to your reminders as server-sent events",
+			Scopes:  remindersRead,
+			Status:  http.StatusOK, Events: v1.ReminderEvent{},
+			handle: (*Handler).handleStreamEvents,
+		},
+		{
+			Method: http.MethodGet, Path: "/openapi.json", Name: "getOpenAPI", Tag: "meta",
+			Summary: "Get this OpenAPI document",
+			Public:  true,
+			Status:  http.StatusOK, Response: map[string]any{},
+			handle: (*Handler).handleGetOpenAPI,
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reminder-app/lib/channel/twilio"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// log any errors but always answer with TwiML, or Twilio reports the webhook
+// as failing.
+func (h *Handler) handleTwilioWebhook(c *gin.Context) {
+	if err := h.verifyTwilioWebhook(c); err != nil {
+		fmt.Println("error verifying twilio webhook: ", err)
+		writeTwiML(c, "")
+		return
+	}
+
+	text, err := h.smsController.HandleMessage(c.Request.Context(), c.PostForm("From"), c.PostForm("Body"))
+	if err != nil {
+		fmt.Println("error handling twilio webhook: ", err)
+		writeTwiML(c, "")
+		return
+	}
+
+	writeTwiML(c, text)
+}
+
+func (h *Handler) verifyTwilioWebhook(c *gin.Context) error {
+	if err := c.Request.ParseForm(); err != nil {
+		return err
+	}
+	// Twilio signs the public URL it posts to, which the request's host
+	// doesn't match behind a proxy.
+	webhookURL := strings.TrimSuffix(h.config.App.APIURL, "/") + c.Request.URL.RequestURI()
+	if !twilio.ValidSignature(h.config.Twilio.AuthToken, webhookURL, c.Request.PostForm, c.GetHeader(twilio.SignatureHeader)) {
+		return errors.New("missing or wrong signature")
+	}
+	return nil
+}
+
+func writeTwiML(c *gin.Context, text string) {
+	body, err := twilio.MessagingResponse(text)
+	if err != nil {
+		fmt.Println("error writing twiml: ", err)
+		c.Status(http.StatusOK)
+		return
+	}
+	c.Data(http.StatusOK, "text/xml; charset=utf-8", body)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"reminder-app/lib/actor"
+	"reminder-app/lib/channel/telegram"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) handleCreateTelegramLink(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	link, err := h.telegramController.CreateLink(actor.GetUserIDInt64())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
+// log any errors but always return 200, or Telegram redelivers the update.
+func (h *Handler) handleTelegramWebhook(c *gin.Context) {
+	if err := h.verifyTelegramWebhook(c); err != nil {
+		fmt.Println("error verifying telegram webhook: ", err)
+		c.Status(http.StatusOK)
+		return
+	}
+
+	var update telegram.Update
+	if err := c.ShouldBindJSON(&update); err != nil {
+		fmt.Println("error parsing telegram webhook: ", err)
+		c.Status(http.StatusOK)
+		return
+	}
+
+	if err := h.telegramController.HandleUpdate(c.Request.Context(), &update); err != nil {
+		fmt.Println("error handling telegram webhook: ", err)
+		c.Status(http.StatusOK)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) verifyTelegramWebhook(c *gin.Context) error {
+	secret := h.config.Telegram.WebhookSecret
+	got := c.GetHeader(telegram.SecretHeader)
+	if secret == "" || subtle.ConstantTimeCompare([]byte(got), []byte(secret)) != 1 {
+		return errors.New("missing or wrong secret token")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/actor"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) handleGetTokens(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	tokens, err := h.tokenController.GetTokens(actor.GetUserIDInt64())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *Handler) handleCreateToken(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	var req protocol.CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	token, err := h.tokenController.CreateToken(actor.GetUserIDInt64(), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+func (h *Handler) handleDeleteToken(c *gin.Context) {
+	actor := actor.FromGin(c)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: "invalid token id"})
+		return
+	}
+
+	if err := h.tokenController.DeleteToken(actor.GetUserIDInt64(), id); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protocol.DeleteResponse{Message: "token deleted"})
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/etag"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxPatchBytes = 1 << 20
+
+// writeVersioned responds with a resource and the ETag clients send back in
+// If-Match to update it without overwriting someone else's changes.
+func writeVersioned(c *gin.Context, status int, v any, updatedAt time.Time) {
+	c.Header("ETag", etag.For(updatedAt))
+	c.JSON(status, v)
+}
+
+// readMergePatch reads a JSON Merge Patch body, responding with an error and
+// returning false if there isn't one.
+func readMergePatch(c *gin.Context) ([]byte, bool) {
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if mediaType != "application/merge-patch+json" && mediaType != "application/json" {
+		c.JSON(http.StatusUnsupportedMediaType, protocol.ErrorResponse{Error: "patches must be application/merge-patch+json"})
+		return nil, false
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPatchBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, protocol.ErrorResponse{Error: err.Error()})
+		return nil, false
+	}
+	return patch, true
+}
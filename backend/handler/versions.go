@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersion is a set of routes mounted under Prefix.
+type APIVersion struct {
+	Prefix string
+	Routes func() []Route
+
+	// Deprecated versions answer with Deprecation and Sunset headers and a
+	// link to the same path under Successor. OperationSuffix keeps their
+	// operation IDs apart from the successor's in the spec.
+	Deprecated      time.Time
+	Sunset          time.Time
+	Successor       string
+	OperationSuffix string
+}
+
+func (v APIVersion) IsDeprecated() bool {
+	return !v.Deprecated.IsZero()
+}
+
+// APIVersions returns every version the server mounts, newest first.
+func APIVersions() []APIVersion {
+	return []APIVersion{
+		{Prefix: "/api/v1", Routes: V1Routes},
+		// The unversioned paths predate /api/v1 and are kept as an alias of it
+		// until they're sunset.
+		{
+			Prefix:          "/api",
+			Routes:          V1Routes,
+			Deprecated:      time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+			Sunset:          time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC),
+			Successor:       "/api/v1",
+			OperationSuffix: "Unversioned",
+		},
+	}
+}
+
+// LatestVersion is the version new clients should use.
+func LatestVersion() APIVersion {
+	return APIVersions()[0]
+}
+
+// deprecationMiddleware announces that a version is going away, as in RFC 9745
+// and RFC 8594.
+func deprecationMiddleware(version APIVersion) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", version.Deprecated.Unix())
+	sunset := version.Sunset.Format(http.TimeFormat)
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		if !version.Sunset.IsZero() {
+			c.Header("Sunset", sunset)
+		}
+		if version.Successor != "" {
+			successor := version.Successor + strings.TrimPrefix(c.Request.URL.Path, version.Prefix)
+			c.Header("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+		}
+		c.Next()
+	}
+}
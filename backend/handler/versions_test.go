@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDeprecatedVersionHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := (&Handler{Engine: gin.New()}).init()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, want 200", w.Code)
+	}
+	if got := w.Header().Get("Deprecation"); got == "" {
+		t.Error("unversioned path has no Deprecation header")
+	}
+	if got := w.Header().Get("Sunset"); got == "" {
+		t.Error("unversioned path has no Sunset header")
+	}
+	if got, want := w.Header().Get("Link"), `</api/v1/openapi.json>; rel="successor-version"`; got != want {
+		t.Errorf("got Link %q, want %q", got, want)
+	}
+
+	// Deprecation is announced before authentication, so rejected requests see it too.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/reminders", nil))
+	if w.Code != http.StatusUnauthorized || w.Header().Get("Deprecation") == "" {
+		t.Errorf("got status %d and Deprecation %q, want 401 with a Deprecation header", w.Code, w.Header().Get("Deprecation"))
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	if got := w.Header().Get("Deprecation"); got != "" {
+		t.Errorf("v1 has Deprecation %q", got)
+	}
+}
@@ -37,7 +37,42 @@ func (h *Handler) handleClerkWebhook(c *gin.Context) {
 }
 
 func (h *Handler) verifyClerkWebhook(c *gin.Context) ([]byte, error) {
-	wh, err := svix.NewWebhook(h.config.Clerk.WebhookSecretKey)
+	return verifySvixWebhook(c, h.config.Clerk.WebhookSecretKey)
+}
+
+// log any errors but always return 200 for webhooks.
+func (h *Handler) handleResendWebhook(c *gin.Context) {
+	payload, err := verifySvixWebhook(c, h.config.Resend.InboundWebhookSecretKey)
+	if err != nil {
+		fmt.Println("error verifying resend webhook: ", err)
+		c.Status(http.StatusOK)
+		return
+	}
+
+	var event protocol.ResendEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		fmt.Println("error parsing resend webhook: ", err)
+		c.Status(http.StatusOK)
+		return
+	}
+	if event.Type != "email.received" {
+		c.Status(http.StatusOK)
+		return
+	}
+
+	if err := h.emailController.HandleReceived(c.Request.Context(), event.Data.EmailID); err != nil {
+		fmt.Println("error handling resend webhook: ", err)
+		c.Status(http.StatusOK)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// verifySvixWebhook checks the signature of webhooks sent through Svix, as
+// Clerk's and Resend's are, and returns the payload.
+func verifySvixWebhook(c *gin.Context, secret string) ([]byte, error) {
+	wh, err := svix.NewWebhook(secret)
 	if err != nil {
 		return nil, err
 	}
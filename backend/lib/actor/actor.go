@@ -1,14 +1,26 @@
 package actor
 
-import "github.com/gin-gonic/gin"
+import (
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
 
 type Actor struct {
 	UserID  uint
 	ClerkID string
+
+	// TokenID is set when the request was authenticated with a personal access
+	// token rather than a session, and restricts the actor to Scopes.
+	TokenID *uint
+	Scopes  []string
+
+	// Memberships maps household ID to the actor's role in that household.
+	Memberships map[int64]string
 }
 
 func New(id uint, clerkID string) *Actor {
-	return &Actor{UserID: id, ClerkID: clerkID}
+	return &Actor{UserID: id, ClerkID: clerkID, Memberships: map[int64]string{}}
 }
 
 func (a *Actor) GetUserIDInt64() int64 {
@@ -19,6 +31,38 @@ func (a *Actor) GetClerkID() string {
 	return a.ClerkID
 }
 
+func (a *Actor) IsToken() bool {
+	return a.TokenID != nil
+}
+
+// HasScope reports whether the actor may perform actions covered by scope.
+// Session actors have every scope.
+func (a *Actor) HasScope(scope string) bool {
+	if !a.IsToken() {
+		return true
+	}
+	return slices.Contains(a.Scopes, scope)
+}
+
+func (a *Actor) IsMember(householdID int64) bool {
+	_, ok := a.Memberships[householdID]
+	return ok
+}
+
+// HasRole reports whether the actor holds one of roles in the household.
+func (a *Actor) HasRole(householdID int64, roles ...string) bool {
+	role, ok := a.Memberships[householdID]
+	return ok && slices.Contains(roles, role)
+}
+
+func (a *Actor) HouseholdIDs() []int64 {
+	ids := make([]int64, 0, len(a.Memberships))
+	for id := range a.Memberships {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 func FromGin(c *gin.Context) *Actor {
 	user, exists := c.Get("user")
 	if !exists {
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reminder-app/lib/actor"
+	"reminder-app/models"
+
+	"gorm.io/gorm"
+)
+
+var ErrUnauthenticated = errors.New("invalid or expired token")
+
+const (
+	ScopeRemindersRead       = "reminders:read"
+	ScopeRemindersWrite      = "reminders:write"
+	ScopeContactMethodsRead  = "contact_methods:read"
+	ScopeContactMethodsWrite = "contact_methods:write"
+)
+
+var Scopes = []string{
+	ScopeRemindersRead,
+	ScopeRemindersWrite,
+	ScopeContactMethodsRead,
+	ScopeContactMethodsWrite,
+}
+
+// Provider turns a bearer token into the actor making the request.
+type Provider interface {
+	Authenticate(ctx context.Context, token string) (*actor.Actor, error)
+}
+
+// UserDeleter removes a user from the identity provider when their account is
+// deleted. Subjects that are already gone are not an error.
+type UserDeleter interface {
+	DeleteUser(ctx context.Context, subject string) error
+}
+
+// LoadActor looks up the user linked to an identity provider subject.
+// Users are keyed by clerk_id regardless of which provider issued the token.
+func LoadActor(db *gorm.DB, subject string) (*actor.Actor, error) {
+	var user models.User
+	if err := db.First(&user, "clerk_id = ?", subject).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUnauthenticated
+		}
+		return nil, fmt.Errorf("error loading user: %w", err)
+	}
+	return ActorForUser(db, user)
+}
+
+// ActorForUser builds the actor for user, including their household memberships.
+func ActorForUser(db *gorm.DB, user models.User) (*actor.Actor, error) {
+	var memberships []models.HouseholdMember
+	if err := db.Where("user_id = ?", user.ID).Find(&memberships).Error; err != nil {
+		return nil, fmt.Errorf("error loading household memberships: %w", err)
+	}
+
+	a := actor.New(user.ID, user.ClerkID)
+	for _, membership := range memberships {
+		a.Memberships[membership.HouseholdID] = membership.Role
+	}
+	return a, nil
+}
@@ -0,0 +1,81 @@
+package clerk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reminder-app/lib/actor"
+	"reminder-app/lib/auth"
+	"sync"
+
+	clerksdk "github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/jwt"
+	"github.com/clerk/clerk-sdk-go/v2/user"
+	"gorm.io/gorm"
+)
+
+var (
+	_ auth.Provider    = &Provider{}
+	_ auth.UserDeleter = &Provider{}
+)
+
+// Provider verifies Clerk session tokens against the instance's JWKS.
+type Provider struct {
+	db *gorm.DB
+
+	mu   sync.RWMutex
+	keys map[string]*clerksdk.JSONWebKey
+}
+
+func New(db *gorm.DB, secretKey string) *Provider {
+	clerksdk.SetKey(secretKey)
+	return &Provider{db: db, keys: map[string]*clerksdk.JSONWebKey{}}
+}
+
+func (p *Provider) Authenticate(ctx context.Context, token string) (*actor.Actor, error) {
+	decoded, err := jwt.Decode(ctx, &jwt.DecodeParams{Token: token})
+	if err != nil {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	jwk, err := p.getJWK(ctx, decoded.KeyID)
+	if err != nil {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	claims, err := jwt.Verify(ctx, &jwt.VerifyParams{Token: token, JWK: jwk})
+	if err != nil {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	return auth.LoadActor(p.db, claims.Subject)
+}
+
+func (p *Provider) DeleteUser(ctx context.Context, subject string) error {
+	_, err := user.Delete(ctx, subject)
+	var apiErr *clerksdk.APIErrorResponse
+	if errors.As(err, &apiErr) && apiErr.HTTPStatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// getJWK caches keys by ID so that we only hit the JWKS endpoint when Clerk rotates keys.
+func (p *Provider) getJWK(ctx context.Context, keyID string) (*clerksdk.JSONWebKey, error) {
+	p.mu.RLock()
+	jwk, ok := p.keys[keyID]
+	p.mu.RUnlock()
+	if ok {
+		return jwk, nil
+	}
+
+	jwk, err := jwt.GetJSONWebKey(ctx, &jwt.GetJSONWebKeyParams{KeyID: keyID})
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.keys[keyID] = jwk
+	p.mu.Unlock()
+	return jwk, nil
+}
@@ -0,0 +1,80 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"reminder-app/lib/actor"
+	"reminder-app/lib/auth"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"gorm.io/gorm"
+)
+
+var (
+	_ auth.Provider    = &Provider{}
+	_ auth.UserDeleter = &Provider{}
+)
+
+// Provider issues and validates HS256 JWTs signed with a shared secret, so the
+// backend can run without a Clerk instance (offline development, tests).
+type Provider struct {
+	db     *gorm.DB
+	secret []byte
+	issuer string
+}
+
+func New(db *gorm.DB, secret string, issuer string) (*Provider, error) {
+	if len(secret) < 32 {
+		return nil, errors.New("local auth secret must be at least 32 bytes")
+	}
+	return &Provider{db: db, secret: []byte(secret), issuer: issuer}, nil
+}
+
+// Mint signs a token for the given subject (a user's clerk_id) valid for ttl.
+func (p *Provider) Mint(subject string, ttl time.Duration) (string, error) {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: p.secret}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := jwt.Claims{
+		Issuer:   p.issuer,
+		Subject:  subject,
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(ttl)),
+	}
+	return jwt.Signed(signer).Claims(claims).CompactSerialize()
+}
+
+func (p *Provider) Authenticate(ctx context.Context, token string) (*actor.Actor, error) {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, auth.ErrUnauthenticated
+	}
+	if len(parsed.Headers) != 1 || parsed.Headers[0].Algorithm != string(jose.HS256) {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	var claims jwt.Claims
+	if err := parsed.Claims(p.secret, &claims); err != nil {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	expected := jwt.Expected{Issuer: p.issuer, Time: time.Now()}
+	if err := claims.ValidateWithLeeway(expected, time.Minute); err != nil {
+		return nil, auth.ErrUnauthenticated
+	}
+	if claims.Subject == "" || claims.Expiry == nil {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	return auth.LoadActor(p.db, claims.Subject)
+}
+
+// DeleteUser is a no-op: local identities only exist as rows in users.
+func (p *Provider) DeleteUser(ctx context.Context, subject string) error {
+	return nil
+}
@@ -0,0 +1,87 @@
+package pat
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"reminder-app/lib/actor"
+	"reminder-app/lib/auth"
+	"reminder-app/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TokenPrefix marks a bearer token as a personal access token so it can be told
+// apart from provider-issued JWTs without a database lookup.
+const TokenPrefix = "uchi_pat_"
+
+var _ auth.Provider = &Provider{}
+
+// Provider authenticates personal access tokens and hands every other token to
+// Fallback (the session provider).
+type Provider struct {
+	db       *gorm.DB
+	Fallback auth.Provider
+}
+
+func New(db *gorm.DB, fallback auth.Provider) *Provider {
+	return &Provider{db: db, Fallback: fallback}
+}
+
+// Generate returns a new random token and the hash to store for it.
+func Generate() (token string, hash string, err error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = TokenPrefix + strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+	return token, Hash(token), nil
+}
+
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (p *Provider) Authenticate(ctx context.Context, token string) (*actor.Actor, error) {
+	if !strings.HasPrefix(token, TokenPrefix) {
+		return p.Fallback.Authenticate(ctx, token)
+	}
+
+	var apiToken models.APIToken
+	if err := p.db.First(&apiToken, "token_hash = ?", Hash(token)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, auth.ErrUnauthenticated
+		}
+		return nil, fmt.Errorf("error loading api token: %w", err)
+	}
+
+	now := time.Now()
+	if now.After(apiToken.ExpiresAt) {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	var user models.User
+	if err := p.db.First(&user, "id = ?", apiToken.UserID).Error; err != nil {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	if err := p.db.Model(&apiToken).UpdateColumn("last_used_at", now).Error; err != nil {
+		log.Printf("Failed to update last_used_at for api token %d: %v", apiToken.ID, err)
+	}
+
+	a, err := auth.ActorForUser(p.db, user)
+	if err != nil {
+		return nil, err
+	}
+	a.TokenID = &apiToken.ID
+	a.Scopes = apiToken.Scopes
+	return a, nil
+}
@@ -0,0 +1,121 @@
+// Package channel renders reminders for chat and notification services that
+// are posted to over HTTP. Each service is a subpackage implementing Sender.
+package channel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Message is a reminder occurrence as channels render it.
+type Message struct {
+	Title string
+	Body  string
+	// Schedule describes when the reminder repeats, such as "Every 2 hours".
+	Schedule string
+	// At is the occurrence being delivered.
+	At    time.Time
+	Links []Link
+	// Priority is how urgently the recipient should be interrupted, for
+	// services that can say.
+	Priority Priority
+	Tags     []string
+	// Actions are replies the recipient can make from the service itself,
+	// for services that call back into the app.
+	Actions []Action
+}
+
+type Priority int
+
+const (
+	PriorityDefault Priority = iota
+	PriorityLow
+	PriorityHigh
+	PriorityUrgent
+)
+
+// Link is an action the recipient can take, opened in their browser.
+type Link struct {
+	Label string
+	URL   string
+}
+
+// Action is a button that sends Data back to the app when pressed.
+type Action struct {
+	Label string
+	Data  string
+}
+
+// Sender delivers messages to a target, the value of a contact method.
+type Sender interface {
+	Send(ctx context.Context, target string, message Message) error
+}
+
+// RateLimitedError is returned when a service asks to be retried later.
+type RateLimitedError struct {
+	Service    string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("%s rate limited the request, retry after %s", e.Service, e.RetryAfter)
+}
+
+// defaultRetryAfter is used when a service rate limits without saying for
+// how long.
+const defaultRetryAfter = time.Minute
+
+// RetryAfter reads a Retry-After header in either of its forms, seconds or
+// an HTTP-date.
+func RetryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return defaultRetryAfter
+}
+
+// NewJSONRequest returns a request with v as its JSON body.
+func NewJSONRequest(ctx context.Context, method string, url string, v any) (*http.Request, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// Do sends req to service, turning a 429 into a *RateLimitedError and any
+// other unsuccessful status into an error carrying the start of the body.
+func Do(client *http.Client, service string, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return &RateLimitedError{Service: service, RetryAfter: RetryAfter(resp)}
+	case resp.StatusCode >= 300:
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s responded %d: %s", service, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Package discord posts reminders to Discord webhooks as embeds.
+package discord
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reminder-app/lib/channel"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var _ channel.Sender = (*Sender)(nil)
+
+// color is the embed's accent, the app's blue.
+const color = 0x2563eb
+
+// maxDescription is the most characters Discord accepts in an embed's
+// description.
+const maxDescription = 4096
+
+type Sender struct {
+	client *http.Client
+}
+
+func New(client *http.Client) *Sender {
+	return &Sender{client: client}
+}
+
+// ValidateWebhookURL checks that target is a Discord webhook, so reminders
+// can't be posted to arbitrary URLs.
+func ValidateWebhookURL(target string) error {
+	u, err := url.Parse(target)
+	validHost := u != nil && (u.Host == "discord.com" || u.Host == "discordapp.com")
+	if err != nil || u.Scheme != "https" || !validHost || !strings.HasPrefix(u.Path, "/api/webhooks/") {
+		return errors.New("discord contact methods must be a webhook URL (https://discord.com/api/webhooks/...)")
+	}
+	return nil
+}
+
+func (s *Sender) Send(ctx context.Context, target string, message channel.Message) error {
+	if err := ValidateWebhookURL(target); err != nil {
+		return err
+	}
+	req, err := channel.NewJSONRequest(ctx, http.MethodPost, target, render(message))
+	if err != nil {
+		return err
+	}
+	return channel.Do(s.client, "Discord", req)
+}
+
+type payload struct {
+	Embeds []embed `json:"embeds"`
+	// AllowedMentions stops reminder bodies from pinging anyone.
+	AllowedMentions allowedMentions `json:"allowed_mentions"`
+}
+
+type embed struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	URL         string  `json:"url,omitempty"`
+	Color       int     `json:"color"`
+	Fields      []field `json:"fields,omitempty"`
+	Timestamp   string  `json:"timestamp"`
+}
+
+type field struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type allowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+func render(message channel.Message) payload {
+	e := embed{
+		Title:       message.Title,
+		Description: description(message),
+		Color:       color,
+		Timestamp:   message.At.UTC().Format(time.RFC3339),
+	}
+	if message.Schedule != "" {
+		e.Fields = append(e.Fields, field{Name: "Schedule", Value: message.Schedule, Inline: true})
+	}
+
+	// Webhooks that don't belong to an application can't send buttons, so
+	// actions are links: the title opens the first one.
+	if len(message.Links) > 0 {
+		e.URL = message.Links[0].URL
+		var links []string
+		for _, link := range message.Links {
+			links = append(links, "["+link.Label+"]("+link.URL+")")
+		}
+		e.Fields = append(e.Fields, field{Name: "Actions", Value: strings.Join(links, " · "), Inline: true})
+	}
+
+	return payload{Embeds: []embed{e}, AllowedMentions: allowedMentions{Parse: []string{}}}
+}
+
+// description is the message's body, cut short with a link to the app, where
+// it can be read in full, if it's too long for an embed.
+func description(message channel.Message) string {
+	body := []rune(message.Body)
+	if len(body) <= maxDescription {
+		return message.Body
+	}
+
+	more := "…"
+	if len(message.Links) > 0 {
+		more += "\n\n[" + message.Links[0].Label + "](" + message.Links[0].URL + ")"
+	}
+	return string(body[:maxDescription-utf8.RuneCountInString(more)]) + more
+}
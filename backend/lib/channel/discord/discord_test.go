@@ -0,0 +1,31 @@
+package discord
+
+import (
+	"reminder-app/lib/channel"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRenderLongBody(t *testing.T) {
+	got := render(channel.Message{
+		Title: "Water the plants",
+		Body:  strings.Repeat("é", 5000),
+		Links: []channel.Link{{Label: "Open reminders", URL: "https://app.example.com"}},
+	})
+
+	description := got.Embeds[0].Description
+	if n := utf8.RuneCountInString(description); n != maxDescription {
+		t.Errorf("description has %d characters, want %d", n, maxDescription)
+	}
+	if !strings.HasSuffix(description, "…\n\n[Open reminders](https://app.example.com)") {
+		t.Errorf("description doesn't end with an ellipsis and a link to the app")
+	}
+}
+
+func TestRenderShortBody(t *testing.T) {
+	got := render(channel.Message{Title: "Water the plants", Body: "Fern and cactus"})
+	if description := got.Embeds[0].Description; description != "Fern and cactus" {
+		t.Errorf("description = %q, want %q", description, "Fern and cactus")
+	}
+}
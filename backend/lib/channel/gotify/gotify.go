@@ -0,0 +1,131 @@
+// Package gotify pushes reminders to self-hosted Gotify servers
+// (https://gotify.net) as application messages.
+package gotify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reminder-app/lib/channel"
+	"strings"
+)
+
+var _ channel.Sender = (*Sender)(nil)
+
+type Sender struct {
+	client *http.Client
+}
+
+func New(client *http.Client) *Sender {
+	return &Sender{client: client}
+}
+
+// Target is the server and application a contact method pushes to.
+type Target struct {
+	Server string
+	// Token is the application's token, which messages are sent as.
+	Token string
+}
+
+// ParseTarget reads a contact method's value: the server's URL with the
+// application token as its token parameter, such as
+// https://gotify.example.com/?token=AbCdEf.
+func ParseTarget(value string) (Target, error) {
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return Target{}, errors.New("gotify contact methods must be a server URL (https://gotify.example.com/?token=...)")
+	}
+	token := u.Query().Get("token")
+	if token == "" {
+		return Target{}, errors.New("gotify contact methods need an application token (https://gotify.example.com/?token=...)")
+	}
+	return Target{
+		Server: u.Scheme + "://" + u.Host + strings.TrimRight(u.Path, "/"),
+		Token:  token,
+	}, nil
+}
+
+// ValidateTarget checks a contact method's value. The server must be https,
+// since every request carries the token.
+func ValidateTarget(value string) error {
+	if _, err := ParseTarget(value); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(value, "https://") {
+		return errors.New("gotify server URLs must be https")
+	}
+	return nil
+}
+
+func (s *Sender) Send(ctx context.Context, target string, message channel.Message) error {
+	t, err := ParseTarget(target)
+	if err != nil {
+		return err
+	}
+	req, err := channel.NewJSONRequest(ctx, http.MethodPost, t.Server+"/message", render(message))
+	if err != nil {
+		return err
+	}
+	// The header keeps the token out of proxies' access logs.
+	req.Header.Set("X-Gotify-Key", t.Token)
+	return channel.Do(s.client, "Gotify", req)
+}
+
+type payload struct {
+	Title    string         `json:"title"`
+	Message  string         `json:"message"`
+	Priority int            `json:"priority"`
+	Extras   map[string]any `json:"extras"`
+}
+
+func render(message channel.Message) payload {
+	// Gotify has no tags, so they're listed under the body with the schedule.
+	body := message.Body
+	var details []string
+	if message.Schedule != "" {
+		details = append(details, "*"+message.Schedule+"*")
+	}
+	if len(message.Tags) > 0 {
+		details = append(details, "#"+strings.Join(message.Tags, " #"))
+	}
+	if len(details) > 0 {
+		body += "\n\n" + strings.Join(details, " · ")
+	}
+	var links []string
+	for _, link := range message.Links {
+		links = append(links, "["+link.Label+"]("+link.URL+")")
+	}
+	if len(links) > 0 {
+		body += "\n\n" + strings.Join(links, " · ")
+	}
+
+	extras := map[string]any{
+		"client::display": map[string]string{"contentType": "text/markdown"},
+	}
+	if len(message.Links) > 0 {
+		extras["client::notification"] = map[string]any{
+			"click": map[string]string{"url": message.Links[0].URL},
+		}
+	}
+	return payload{
+		Title:    message.Title,
+		Message:  body,
+		Priority: priority(message.Priority),
+		Extras:   extras,
+	}
+}
+
+// priority maps onto Gotify's 0 to 10. The Android app notifies silently
+// below 4 and pops up from 8.
+func priority(p channel.Priority) int {
+	switch p {
+	case channel.PriorityLow:
+		return 2
+	case channel.PriorityHigh:
+		return 7
+	case channel.PriorityUrgent:
+		return 9
+	}
+	return 5
+}
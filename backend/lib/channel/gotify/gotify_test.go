@@ -0,0 +1,92 @@
+package gotify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reminder-app/lib/channel"
+	"strings"
+	"testing"
+)
+
+func TestSend(t *testing.T) {
+	var got struct {
+		Title    string `json:"title"`
+		Message  string `json:"message"`
+		Priority int    `json:"priority"`
+		Extras   struct {
+			Notification struct {
+				Click struct {
+					URL string `json:"url"`
+				} `json:"click"`
+			} `json:"client::notification"`
+		} `json:"extras"`
+	}
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/gotify/message" {
+			t.Errorf("got %s %s, want POST /gotify/message", r.Method, r.URL.Path)
+		}
+		if r.URL.RawQuery != "" {
+			t.Errorf("sent the query %q, which leaks the token", r.URL.RawQuery)
+		}
+		token = r.Header.Get("X-Gotify-Key")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	message := channel.Message{
+		Title:    "Reminder",
+		Body:     "Take out the bins",
+		Schedule: "Every week",
+		Links:    []channel.Link{{Label: "Open reminders", URL: "https://app.example.com"}},
+		Priority: channel.PriorityUrgent,
+		Tags:     []string{"chores"},
+	}
+	target := server.URL + "/gotify/?token=AbCdEf"
+	if err := New(server.Client()).Send(context.Background(), target, message); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if token != "AbCdEf" {
+		t.Errorf("got token %q, want AbCdEf", token)
+	}
+	if got.Title != "Reminder" || !strings.HasPrefix(got.Message, "Take out the bins\n\n*Every week* · #chores") {
+		t.Errorf("got title %q and message %q", got.Title, got.Message)
+	}
+	if got.Priority != 9 {
+		t.Errorf("got priority %d, want 9", got.Priority)
+	}
+	if got.Extras.Notification.Click.URL != "https://app.example.com" {
+		t.Errorf("got click URL %q", got.Extras.Notification.Click.URL)
+	}
+}
+
+func TestSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	err := New(server.Client()).Send(context.Background(), server.URL+"/?token=wrong", channel.Message{})
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("got %v, want an error with the status", err)
+	}
+}
+
+func TestValidateTarget(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"https://gotify.example.com/?token=AbCdEf": true,
+		"https://example.com/gotify?token=AbCdEf":  true,
+		"https://gotify.example.com/":              false,
+		"http://gotify.example.com/?token=AbCdEf":  false,
+		"gotify.example.com/?token=AbCdEf":         false,
+	} {
+		if err := ValidateTarget(value); (err == nil) != valid {
+			t.Errorf("ValidateTarget(%q) = %v, want valid %t", value, err, valid)
+		}
+	}
+}
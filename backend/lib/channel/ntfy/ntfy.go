@@ -0,0 +1,148 @@
+// Package ntfy publishes reminders to ntfy topics (https://ntfy.sh), on the
+// public server or a self-hosted one.
+package ntfy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"reminder-app/lib/channel"
+	"strings"
+)
+
+var _ channel.Sender = (*Sender)(nil)
+
+// maxActions is how many action buttons ntfy shows on a notification.
+const maxActions = 3
+
+var topicPattern = regexp.MustCompile(`^[-_A-Za-z0-9]{1,64}$`)
+
+type Sender struct {
+	client *http.Client
+	// server is where topics given without a server are published.
+	server string
+}
+
+func New(client *http.Client, server string) *Sender {
+	return &Sender{client: client, server: strings.TrimRight(server, "/")}
+}
+
+// Target is where a contact method publishes to.
+type Target struct {
+	Server string
+	Topic  string
+	// Token is an access token for servers that protect the topic.
+	Token string
+}
+
+// ParseTarget reads a contact method's value: either a bare topic, published
+// on defaultServer, or a topic URL such as https://ntfy.example.com/chores,
+// optionally with an access token as its user (https://tk_...@ntfy.example.com/chores).
+func ParseTarget(value string, defaultServer string) (Target, error) {
+	if topicPattern.MatchString(value) {
+		return Target{Server: strings.TrimRight(defaultServer, "/"), Topic: value}, nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return Target{}, errors.New("ntfy contact methods must be a topic or a topic URL (https://ntfy.sh/your-topic)")
+	}
+	// Servers can be hosted under a path, so the topic is the last segment.
+	path := strings.TrimRight(u.Path, "/")
+	i := strings.LastIndex(path, "/")
+	topic := path[i+1:]
+	if !topicPattern.MatchString(topic) {
+		return Target{}, errors.New("ntfy topics are up to 64 letters, digits, dashes and underscores")
+	}
+
+	target := Target{
+		Server: u.Scheme + "://" + u.Host + path[:i],
+		Topic:  topic,
+	}
+	if u.User != nil {
+		target.Token = u.User.Username()
+	}
+	return target, nil
+}
+
+// ValidateTarget checks a contact method's value. Topic URLs must be https,
+// since they may carry an access token.
+func ValidateTarget(value string) error {
+	if _, err := ParseTarget(value, ""); err != nil {
+		return err
+	}
+	if !topicPattern.MatchString(value) && !strings.HasPrefix(value, "https://") {
+		return errors.New("ntfy topic URLs must be https")
+	}
+	return nil
+}
+
+func (s *Sender) Send(ctx context.Context, target string, message channel.Message) error {
+	t, err := ParseTarget(target, s.server)
+	if err != nil {
+		return err
+	}
+	// Publishing JSON goes to the server's root, naming the topic in the body.
+	req, err := channel.NewJSONRequest(ctx, http.MethodPost, t.Server+"/", render(t.Topic, message))
+	if err != nil {
+		return err
+	}
+	if t.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.Token)
+	}
+	return channel.Do(s.client, "ntfy", req)
+}
+
+type payload struct {
+	Topic    string   `json:"topic"`
+	Title    string   `json:"title"`
+	Message  string   `json:"message"`
+	Priority int      `json:"priority"`
+	Tags     []string `json:"tags"`
+	Click    string   `json:"click,omitempty"`
+	Actions  []action `json:"actions,omitempty"`
+}
+
+type action struct {
+	Action string `json:"action"`
+	Label  string `json:"label"`
+	URL    string `json:"url"`
+}
+
+func render(topic string, message channel.Message) payload {
+	body := message.Body
+	if message.Schedule != "" {
+		body += "\n\n" + message.Schedule
+	}
+
+	p := payload{
+		Topic:    topic,
+		Title:    message.Title,
+		Message:  body,
+		Priority: priority(message.Priority),
+		// Tags that are emoji shortcodes show as emoji before the title.
+		Tags: append([]string{"alarm_clock"}, message.Tags...),
+	}
+	if len(message.Links) > 0 {
+		p.Click = message.Links[0].URL
+	}
+	for _, link := range message.Links[:min(len(message.Links), maxActions)] {
+		p.Actions = append(p.Actions, action{Action: "view", Label: link.Label, URL: link.URL})
+	}
+	return p
+}
+
+// priority maps onto ntfy's 1 (min) to 5 (max), where 3 is the default.
+func priority(p channel.Priority) int {
+	switch p {
+	case channel.PriorityLow:
+		return 2
+	case channel.PriorityHigh:
+		return 4
+	case channel.PriorityUrgent:
+		return 5
+	}
+	return 3
+}
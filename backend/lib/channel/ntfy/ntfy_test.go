@@ -0,0 +1,109 @@
+package ntfy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reminder-app/lib/channel"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSend(t *testing.T) {
+	var got payload
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/" {
+			t.Errorf("got %s %s, want POST /", r.Method, r.URL.Path)
+		}
+		authorization = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	message := channel.Message{
+		Title:    "Reminder",
+		Body:     "Water the plants",
+		Schedule: "Every 2 days",
+		Links: []channel.Link{
+			{Label: "Open reminders", URL: "https://app.example.com"},
+			{Label: "Change where reminders go", URL: "https://app.example.com/settings"},
+		},
+		Priority: channel.PriorityHigh,
+		Tags:     []string{"garden"},
+	}
+	if err := New(server.Client(), server.URL).Send(context.Background(), "chores", message); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if got.Topic != "chores" || got.Title != "Reminder" || got.Message != "Water the plants\n\nEvery 2 days" {
+		t.Errorf("got topic %q, title %q and message %q", got.Topic, got.Title, got.Message)
+	}
+	if got.Priority != 4 {
+		t.Errorf("got priority %d, want 4", got.Priority)
+	}
+	if !slices.Equal(got.Tags, []string{"alarm_clock", "garden"}) {
+		t.Errorf("got tags %q", got.Tags)
+	}
+	if got.Click != "https://app.example.com" || len(got.Actions) != 2 {
+		t.Errorf("got click %q and %d actions", got.Click, len(got.Actions))
+	}
+	if authorization != "" {
+		t.Errorf("sent Authorization %q without a token", authorization)
+	}
+}
+
+func TestSendTopicURL(t *testing.T) {
+	var path, topic, authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		authorization = r.Header.Get("Authorization")
+		var p payload
+		json.NewDecoder(r.Body).Decode(&p)
+		topic = p.Topic
+	}))
+	defer server.Close()
+
+	target := "http://tk_secret@" + server.Listener.Addr().String() + "/ntfy/chores"
+	if err := New(server.Client(), "https://ntfy.sh").Send(context.Background(), target, channel.Message{}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if path != "/ntfy/" || topic != "chores" || authorization != "Bearer tk_secret" {
+		t.Errorf("got path %q, topic %q and Authorization %q", path, topic, authorization)
+	}
+}
+
+func TestSendRateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	err := New(server.Client(), server.URL).Send(context.Background(), "chores", channel.Message{})
+	var rateLimited *channel.RateLimitedError
+	if !errors.As(err, &rateLimited) || rateLimited.RetryAfter != 30*time.Second {
+		t.Errorf("got %v, want a rate limit of 30s", err)
+	}
+}
+
+func TestValidateTarget(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"chores":                         true,
+		"https://ntfy.sh/chores":         true,
+		"https://tk_abc@ntfy.sh/chores":  true,
+		"http://ntfy.example.com/chores": false,
+		"https://ntfy.sh/":               false,
+		"https://ntfy.sh/not a topic":    false,
+		"not a topic":                    false,
+	} {
+		if err := ValidateTarget(value); (err == nil) != valid {
+			t.Errorf("ValidateTarget(%q) = %v, want valid %t", value, err, valid)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Package slack posts reminders to Slack incoming webhooks as Block Kit
+// messages.
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reminder-app/lib/channel"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var _ channel.Sender = (*Sender)(nil)
+
+// maxSectionText is the most characters Slack accepts in a section's text.
+const maxSectionText = 3000
+
+type Sender struct {
+	client *http.Client
+}
+
+func New(client *http.Client) *Sender {
+	return &Sender{client: client}
+}
+
+// ValidateWebhookURL checks that target is a Slack incoming webhook, so
+// reminders can't be posted to arbitrary URLs.
+func ValidateWebhookURL(target string) error {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "https" || u.Host != "hooks.slack.com" || !strings.HasPrefix(u.Path, "/services/") {
+		return errors.New("slack contact methods must be an incoming webhook URL (https://hooks.slack.com/services/...)")
+	}
+	return nil
+}
+
+func (s *Sender) Send(ctx context.Context, target string, message channel.Message) error {
+	if err := ValidateWebhookURL(target); err != nil {
+		return err
+	}
+	req, err := channel.NewJSONRequest(ctx, http.MethodPost, target, render(message))
+	if err != nil {
+		return err
+	}
+	return channel.Do(s.client, "Slack", req)
+}
+
+// payload is an incoming webhook message. Text is the fallback shown in
+// notifications, where blocks aren't rendered.
+type payload struct {
+	Text   string  `json:"text"`
+	Blocks []block `json:"blocks"`
+}
+
+type block struct {
+	Type     string    `json:"type"`
+	Text     *text     `json:"text,omitempty"`
+	Elements []element `json:"elements,omitempty"`
+}
+
+type text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// element is a context block's text or an actions block's button.
+type element struct {
+	Type string `json:"type"`
+	Text any    `json:"text"`
+	URL  string `json:"url,omitempty"`
+}
+
+func render(message channel.Message) payload {
+	blocks := []block{
+		{Type: "header", Text: &text{Type: "plain_text", Text: message.Title}},
+		{Type: "section", Text: &text{Type: "mrkdwn", Text: sectionText(message)}},
+	}
+
+	// Slack renders <!date^...> in the reader's own time zone.
+	when := "<!date^" + strconv.FormatInt(message.At.Unix(), 10) + "^{date_short_pretty} at {time}|" + message.At.UTC().Format(time.RFC1123) + ">"
+	if message.Schedule != "" {
+		when += " · " + escape(message.Schedule)
+	}
+	blocks = append(blocks, block{Type: "context", Elements: []element{{Type: "mrkdwn", Text: when}}})
+
+	if len(message.Links) > 0 {
+		var buttons []element
+		for _, link := range message.Links {
+			buttons = append(buttons, element{
+				Type: "button",
+				Text: text{Type: "plain_text", Text: link.Label},
+				URL:  link.URL,
+			})
+		}
+		blocks = append(blocks, block{Type: "actions", Elements: buttons})
+	}
+
+	return payload{Text: message.Title + ": " + message.Body, Blocks: blocks}
+}
+
+// sectionText is the message's body as mrkdwn. A body too long for a section
+// is cut short and links to the app, where it can be read in full; the
+// fallback text still has all of it.
+func sectionText(message channel.Message) string {
+	body := escape(message.Body)
+	if utf8.RuneCountInString(body) <= maxSectionText {
+		return body
+	}
+
+	more := "…"
+	if len(message.Links) > 0 {
+		more += " <" + message.Links[0].URL + "|" + escape(message.Links[0].Label) + ">"
+	}
+	limit := maxSectionText - utf8.RuneCountInString(more)
+	// Cut before escaping so that no entity is cut in half.
+	var b strings.Builder
+	n := 0
+	for _, r := range message.Body {
+		escaped := escape(string(r))
+		n += utf8.RuneCountInString(escaped)
+		if n > limit {
+			break
+		}
+		b.WriteString(escaped)
+	}
+	return b.String() + more
+}
+
+// escape makes text safe for mrkdwn, where &, < and > are control characters.
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,43 @@
+package slack
+
+import (
+	"reminder-app/lib/channel"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRenderLongBody(t *testing.T) {
+	message := channel.Message{
+		Title: "Water the plants",
+		Body:  strings.Repeat("a < b ", 1000),
+		Links: []channel.Link{{Label: "Open reminders", URL: "https://app.example.com"}},
+	}
+	got := render(message)
+
+	section := got.Blocks[1].Text.Text
+	if n := utf8.RuneCountInString(section); n > maxSectionText {
+		t.Errorf("section has %d characters, want at most %d", n, maxSectionText)
+	}
+	const more = "… <https://app.example.com|Open reminders>"
+	cut, ok := strings.CutSuffix(section, more)
+	if !ok {
+		t.Fatalf("section doesn't end with %q", more)
+	}
+	if !strings.HasPrefix(escape(message.Body), cut) {
+		t.Error("section isn't the start of the escaped body")
+	}
+	if i := strings.LastIndex(cut, "&"); i >= 0 && !strings.Contains(cut[i:], ";") {
+		t.Errorf("section cuts the entity %q in half", cut[i:])
+	}
+	if got.Text != message.Title+": "+message.Body {
+		t.Error("fallback text doesn't have the full body")
+	}
+}
+
+func TestRenderShortBody(t *testing.T) {
+	got := render(channel.Message{Title: "Water the plants", Body: "Fern & cactus"})
+	if section := got.Blocks[1].Text.Text; section != "Fern &amp; cactus" {
+		t.Errorf("section = %q, want %q", section, "Fern &amp; cactus")
+	}
+}
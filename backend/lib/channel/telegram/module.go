@@ -0,0 +1,25 @@
+package telegram
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"reminder-app/config"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// Module provides a nil *Bot when no bot token is configured.
+var Module = fx.Module("telegram",
+	fx.Provide(func(cfg *config.Config) (*Bot, error) {
+		if cfg.Telegram.BotToken == "" {
+			log.Println("TELEGRAM_BOT_TOKEN is not set, Telegram is disabled")
+			return nil, nil
+		}
+		if cfg.Telegram.BotUsername == "" || cfg.Telegram.WebhookSecret == "" {
+			return nil, errors.New("TELEGRAM_BOT_USERNAME and TELEGRAM_WEBHOOK_SECRET are required with TELEGRAM_BOT_TOKEN")
+		}
+		return New(&http.Client{Timeout: 30 * time.Second}, cfg.Telegram.BotToken, cfg.Telegram.BotUsername), nil
+	}),
+)
@@ -0,0 +1,209 @@
+// Package telegram talks to the Telegram Bot API: it delivers reminders to
+// chats with the bot and reads the updates Telegram posts to its webhook.
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"reminder-app/lib/channel"
+	"strconv"
+	"strings"
+)
+
+var _ channel.Sender = (*Bot)(nil)
+
+const apiURL = "https://api.telegram.org"
+
+// SecretHeader carries the secret_token the webhook was set with on every
+// update, so updates can't be forged by anyone who finds its URL.
+const SecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+type Bot struct {
+	client *http.Client
+	token  string
+	// username is the bot's @username without the @.
+	username string
+}
+
+func New(client *http.Client, token string, username string) *Bot {
+	return &Bot{client: client, token: token, username: strings.TrimPrefix(username, "@")}
+}
+
+// Link returns a t.me link that opens a chat with the bot, ready to send it
+// /start with code.
+func (b *Bot) Link(code string) string {
+	return "https://t.me/" + b.username + "?start=" + url.QueryEscape(code)
+}
+
+// Update is what Telegram posts to the webhook. Only the kinds of update the
+// webhook is set to receive are declared.
+type Update struct {
+	UpdateID      int64          `json:"update_id"`
+	Message       *Message       `json:"message"`
+	CallbackQuery *CallbackQuery `json:"callback_query"`
+}
+
+type Message struct {
+	MessageID int64  `json:"message_id"`
+	Chat      Chat   `json:"chat"`
+	Text      string `json:"text"`
+}
+
+type Chat struct {
+	ID int64 `json:"id"`
+	// Type is "private" for chats with a person, or "group", "supergroup"
+	// or "channel".
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+}
+
+// Name describes the chat, for the description of its contact method.
+func (c Chat) Name() string {
+	switch {
+	case c.Title != "":
+		return c.Title
+	case c.Username != "":
+		return "@" + c.Username
+	}
+	return c.FirstName
+}
+
+// CallbackQuery is sent when someone presses an inline keyboard button.
+type CallbackQuery struct {
+	ID string `json:"id"`
+	// Message is the message the button is on.
+	Message *Message `json:"message"`
+	Data    string   `json:"data"`
+}
+
+// Command splits a message such as "/start abc" into its command and
+// argument. Commands in groups may name the bot, as in "/start@bot abc".
+func (m *Message) Command() (command string, argument string) {
+	if !strings.HasPrefix(m.Text, "/") {
+		return "", ""
+	}
+	command, argument, _ = strings.Cut(m.Text, " ")
+	command, _, _ = strings.Cut(command, "@")
+	return command, strings.TrimSpace(argument)
+}
+
+// ParseChatID reads a telegram contact method's value.
+func ParseChatID(value string) (int64, error) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.New("telegram contact methods must be a chat ID")
+	}
+	return id, nil
+}
+
+func (b *Bot) Send(ctx context.Context, target string, message channel.Message) error {
+	chatID, err := ParseChatID(target)
+	if err != nil {
+		return err
+	}
+
+	req := sendMessageRequest{
+		ChatID:             chatID,
+		Text:               render(message),
+		ParseMode:          "HTML",
+		LinkPreviewOptions: &linkPreviewOptions{IsDisabled: true},
+	}
+	// Links go in the text, since Telegram rejects button URLs it can't
+	// reach, such as localhost in development.
+	if len(message.Actions) > 0 {
+		var row []inlineKeyboardButton
+		for _, action := range message.Actions {
+			row = append(row, inlineKeyboardButton{Text: action.Label, CallbackData: action.Data})
+		}
+		req.ReplyMarkup = &inlineKeyboardMarkup{InlineKeyboard: [][]inlineKeyboardButton{row}}
+	}
+	return b.call(ctx, "sendMessage", req)
+}
+
+// Reply sends plain text to a chat, such as an answer to a command.
+func (b *Bot) Reply(ctx context.Context, chatID int64, text string) error {
+	return b.call(ctx, "sendMessage", sendMessageRequest{ChatID: chatID, Text: text})
+}
+
+// AnswerCallbackQuery stops the button's loading spinner, briefly showing
+// text to whoever pressed it.
+func (b *Bot) AnswerCallbackQuery(ctx context.Context, id string, text string) error {
+	return b.call(ctx, "answerCallbackQuery", map[string]string{"callback_query_id": id, "text": text})
+}
+
+// RemoveButtons takes the inline keyboard off a message once it's been used.
+func (b *Bot) RemoveButtons(ctx context.Context, message *Message) error {
+	return b.call(ctx, "editMessageReplyMarkup", map[string]any{
+		"chat_id":      message.Chat.ID,
+		"message_id":   message.MessageID,
+		"reply_markup": inlineKeyboardMarkup{InlineKeyboard: [][]inlineKeyboardButton{}},
+	})
+}
+
+// SetWebhook has Telegram post the bot's messages and button presses to
+// webhookURL, with secret in SecretHeader.
+func (b *Bot) SetWebhook(ctx context.Context, webhookURL string, secret string) error {
+	return b.call(ctx, "setWebhook", map[string]any{
+		"url":             webhookURL,
+		"secret_token":    secret,
+		"allowed_updates": []string{"message", "callback_query"},
+	})
+}
+
+func (b *Bot) call(ctx context.Context, method string, params any) error {
+	req, err := channel.NewJSONRequest(ctx, http.MethodPost, apiURL+"/bot"+b.token+"/"+method, params)
+	if err != nil {
+		return err
+	}
+	err = channel.Do(b.client, "Telegram", req)
+	// The client's errors quote the URL, which holds the token.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("telegram %s failed: %w", method, urlErr.Err)
+	}
+	return err
+}
+
+type sendMessageRequest struct {
+	ChatID             int64                 `json:"chat_id"`
+	Text               string                `json:"text"`
+	ParseMode          string                `json:"parse_mode,omitempty"`
+	LinkPreviewOptions *linkPreviewOptions   `json:"link_preview_options,omitempty"`
+	ReplyMarkup        *inlineKeyboardMarkup `json:"reply_markup,omitempty"`
+}
+
+type linkPreviewOptions struct {
+	IsDisabled bool `json:"is_disabled"`
+}
+
+type inlineKeyboardMarkup struct {
+	InlineKeyboard [][]inlineKeyboardButton `json:"inline_keyboard"`
+}
+
+type inlineKeyboardButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+func render(message channel.Message) string {
+	var b strings.Builder
+	b.WriteString("<b>" + html.EscapeString(message.Title) + "</b>\n\n")
+	b.WriteString(html.EscapeString(message.Body))
+	if message.Schedule != "" {
+		b.WriteString("\n\n<i>" + html.EscapeString(message.Schedule) + "</i>")
+	}
+	if len(message.Links) > 0 {
+		var links []string
+		for _, link := range message.Links {
+			links = append(links, `<a href="`+html.EscapeString(link.URL)+`">`+html.EscapeString(link.Label)+"</a>")
+		}
+		b.WriteString("\n" + strings.Join(links, " · "))
+	}
+	return b.String()
+}
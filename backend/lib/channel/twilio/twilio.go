@@ -0,0 +1,165 @@
+// Package twilio texts reminders to phone contact methods, reads the texts
+// Twilio posts to its SMS webhook and writes the TwiML it answers them with.
+package twilio
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+	"reminder-app/lib/channel"
+	"slices"
+	"strings"
+)
+
+var _ channel.Sender = (*Sender)(nil)
+
+const apiURL = "https://api.twilio.com"
+
+type Sender struct {
+	client     *http.Client
+	apiURL     string
+	accountSID string
+	authToken  string
+	// from is the number texts are sent from, which replies come back to.
+	from string
+}
+
+func New(client *http.Client, accountSID string, authToken string, from string) *Sender {
+	return &Sender{client: client, apiURL: apiURL, accountSID: accountSID, authToken: authToken, from: from}
+}
+
+// PhoneNumber reads a phone contact method's value as an E.164 number. Ten
+// digits are a number in the North American Numbering Plan without its
+// country code, which is how the app saves them.
+func PhoneNumber(value string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, value)
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(value), "+") && len(digits) >= 8 && len(digits) <= 15:
+		return "+" + digits, nil
+	case len(digits) == 10:
+		return "+1" + digits, nil
+	case len(digits) == 11 && strings.HasPrefix(digits, "1"):
+		return "+" + digits, nil
+	}
+	return "", errors.New("phone numbers must have 10 digits, or start with + and the country code")
+}
+
+// NumberVariants returns the digits of a phone number, with and without the
+// country code of a number in the North American Numbering Plan, as phone
+// contact methods are saved either way.
+func NumberVariants(number string) []string {
+	digits := strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, number)
+	switch {
+	case digits == "":
+		return nil
+	case len(digits) == 10:
+		return []string{digits, "1" + digits}
+	case len(digits) == 11 && strings.HasPrefix(digits, "1"):
+		return []string{digits, digits[1:]}
+	}
+	return []string{digits}
+}
+
+func (s *Sender) Send(ctx context.Context, target string, message channel.Message) error {
+	to, err := PhoneNumber(target)
+	if err != nil {
+		return err
+	}
+	form := url.Values{
+		"To":   {to},
+		"From": {s.from},
+		"Body": {render(message)},
+	}
+	endpoint := s.apiURL + "/2010-04-01/Accounts/" + url.PathEscape(s.accountSID) + "/Messages.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	return channel.Do(s.client, "Twilio", req)
+}
+
+// render writes a message as plain text. Texts have no buttons, so a message
+// that can be replied to says how.
+func render(message channel.Message) string {
+	lines := []string{message.Title + ": " + message.Body}
+	if message.Schedule != "" {
+		lines = append(lines, message.Schedule)
+	}
+	if len(message.Actions) > 0 {
+		lines = append(lines, "Reply DONE, or SNOOZE and how long, such as SNOOZE 30.")
+	}
+	lines = append(lines, "Reply STOP to opt out.")
+	return strings.Join(lines, "\n")
+}
+
+// SignatureHeader carries Twilio's signature of every webhook request, so
+// requests can't be forged by anyone who finds the webhook's URL.
+const SignatureHeader = "X-Twilio-Signature"
+
+// Signature signs a webhook request the way Twilio does: an HMAC-SHA1, keyed
+// with the account's auth token, of the full URL the webhook is configured
+// with followed by each form parameter's name and value, sorted by name.
+func Signature(authToken string, webhookURL string, form url.Values) string {
+	var b strings.Builder
+	b.WriteString(webhookURL)
+	keys := make([]string, 0, len(form))
+	for key := range form {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		values := slices.Clone(form[key])
+		slices.Sort(values)
+		for _, value := range values {
+			b.WriteString(key)
+			b.WriteString(value)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(b.String()))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ValidSignature reports whether signature is Twilio's signature of a request
+// to webhookURL with form.
+func ValidSignature(authToken string, webhookURL string, form url.Values, signature string) bool {
+	if authToken == "" || signature == "" {
+		return false
+	}
+	want := Signature(authToken, webhookURL, form)
+	return subtle.ConstantTimeCompare([]byte(want), []byte(signature)) == 1
+}
+
+type messagingResponse struct {
+	XMLName xml.Name `xml:"Response"`
+	Message string   `xml:"Message,omitempty"`
+}
+
+// MessagingResponse returns TwiML that replies to an incoming message with
+// text, or doesn't reply when text is empty.
+func MessagingResponse(text string) ([]byte, error) {
+	b, err := xml.Marshal(messagingResponse{Message: text})
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
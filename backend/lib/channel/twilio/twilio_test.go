@@ -0,0 +1,99 @@
+package twilio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reminder-app/lib/channel"
+	"slices"
+	"testing"
+)
+
+func TestSend(t *testing.T) {
+	var got url.Values
+	var user, password string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2010-04-01/Accounts/AC123/Messages.json" {
+			t.Errorf("got %s %s, want POST /2010-04-01/Accounts/AC123/Messages.json", r.Method, r.URL.Path)
+		}
+		user, password, _ = r.BasicAuth()
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parsing form: %v", err)
+		}
+		got = r.PostForm
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	sender := New(server.Client(), "AC123", "token", "+15550001111")
+	sender.apiURL = server.URL
+	message := channel.Message{
+		Title:    "Reminder",
+		Body:     "Water the plants",
+		Schedule: "Every 2 days",
+		Actions:  []channel.Action{{Label: "Done", Data: "1 done"}},
+	}
+	if err := sender.Send(context.Background(), "5551234567", message); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if user != "AC123" || password != "token" {
+		t.Errorf("got basic auth %q:%q, want AC123:token", user, password)
+	}
+	if got.Get("To") != "+15551234567" || got.Get("From") != "+15550001111" {
+		t.Errorf("got To %q and From %q", got.Get("To"), got.Get("From"))
+	}
+	want := "Reminder: Water the plants\nEvery 2 days\nReply DONE, or SNOOZE and how long, such as SNOOZE 30.\nReply STOP to opt out."
+	if got.Get("Body") != want {
+		t.Errorf("got body %q, want %q", got.Get("Body"), want)
+	}
+}
+
+func TestPhoneNumber(t *testing.T) {
+	for value, want := range map[string]string{
+		"5551234567":       "+15551234567",
+		"(555) 123-4567":   "+15551234567",
+		"15551234567":      "+15551234567",
+		"+44 20 7946 0958": "+442079460958",
+	} {
+		if got, err := PhoneNumber(value); err != nil || got != want {
+			t.Errorf("PhoneNumber(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+	if _, err := PhoneNumber("12345"); err == nil {
+		t.Error("PhoneNumber accepted 12345")
+	}
+}
+
+func TestNumberVariants(t *testing.T) {
+	for number, want := range map[string][]string{
+		"+15551234567":     {"15551234567", "5551234567"},
+		"(555) 123-4567":   {"5551234567", "15551234567"},
+		"+44 20 7946 0958": {"442079460958"},
+		"":                 nil,
+	} {
+		if got := NumberVariants(number); !slices.Equal(got, want) {
+			t.Errorf("NumberVariants(%q) = %q, want %q", number, got, want)
+		}
+	}
+}
+
+// The example from Twilio's webhook security documentation.
+func TestValidSignature(t *testing.T) {
+	form := url.Values{
+		"CallSid": {"CA1234567890ABCDE"},
+		"Caller":  {"+14158675310"},
+		"Digits":  {"1234"},
+		"From":    {"+14158675310"},
+		"To":      {"+18005551212"},
+	}
+	webhookURL := "https://mycompany.com/myapp.php?foo=1&bar=2"
+	if !ValidSignature("12345", webhookURL, form, "GvWf1cFY/Q7PnoempGyD5oXAezc=") {
+		t.Error("rejected Twilio's example signature")
+	}
+	form.Set("Digits", "4321")
+	if ValidSignature("12345", webhookURL, form, "GvWf1cFY/Q7PnoempGyD5oXAezc=") {
+		t.Error("accepted a signature of different parameters")
+	}
+}
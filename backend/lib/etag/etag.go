@@ -0,0 +1,30 @@
+package etag
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// For returns the entity tag of a row last updated at t. Postgres stores
+// microseconds, so anything finer would change once the row is reloaded.
+func For(t time.Time) string {
+	return `"` + strconv.FormatInt(t.UnixMicro(), 36) + `"`
+}
+
+// Matches reports whether an If-Match header allows changing a row last
+// updated at t. An empty header has no precondition.
+func Matches(ifMatch string, t time.Time) bool {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return true
+	}
+	current := For(t)
+	for _, tag := range strings.Split(ifMatch, ",") {
+		// If-Match uses strong comparison, so weak tags never match.
+		if strings.TrimSpace(tag) == current {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package events
+
+import (
+	"encoding/json"
+
+	"gorm.io/gorm"
+)
+
+// Channel is the Postgres NOTIFY channel events travel over, so every backend
+// instance sees events published by the others.
+const Channel = "app_events"
+
+// Event is a message for the streams of UserIDs.
+type Event struct {
+	UserIDs []int64         `json:"user_ids"`
+	Type    string          `json:"type"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Publish sends data to the users' event streams. Published in a transaction,
+// the event is only sent once the transaction commits, and not at all if it,
+// or the savepoint it was published in, rolls back.
+//
+// NOTIFY payloads are limited to 8000 bytes, so data should identify what
+// changed rather than carry it.
+func Publish(db *gorm.DB, userIDs []int64, eventType string, data any) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(Event{UserIDs: userIDs, Type: eventType, Data: encoded})
+	if err != nil {
+		return err
+	}
+	return db.Exec("SELECT pg_notify(?, ?)", Channel, string(payload)).Error
+}
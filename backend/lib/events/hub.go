@@ -0,0 +1,128 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reminder-app/config"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// subscriberBuffer is how many events a slow stream can fall behind by
+	// before it starts missing them.
+	subscriberBuffer = 32
+	reconnectDelay   = 5 * time.Second
+)
+
+// Message is an event as delivered to one user's stream.
+type Message struct {
+	Type string
+	Data json.RawMessage
+}
+
+// Hub listens for published events and fans them out to the streams of the
+// users they're for.
+type Hub struct {
+	databaseURL string
+
+	mu          sync.Mutex
+	subscribers map[int64]map[chan Message]struct{}
+}
+
+func NewHub(cfg *config.Config) *Hub {
+	return &Hub{
+		databaseURL: cfg.DatabaseURL,
+		subscribers: map[int64]map[chan Message]struct{}{},
+	}
+}
+
+// Start listens for events in the background until ctx is cancelled.
+func (h *Hub) Start(ctx context.Context) {
+	go h.run(ctx)
+}
+
+// Subscribe returns the events for userID until unsubscribe is called.
+func (h *Hub) Subscribe(userID int64) (messages <-chan Message, unsubscribe func()) {
+	ch := make(chan Message, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = map[chan Message]struct{}{}
+	}
+	h.subscribers[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers[userID], ch)
+		if len(h.subscribers[userID]) == 0 {
+			delete(h.subscribers, userID)
+		}
+	}
+}
+
+func (h *Hub) dispatch(event Event) {
+	message := Message{Type: event.Type, Data: event.Data}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, userID := range event.UserIDs {
+		for ch := range h.subscribers[userID] {
+			select {
+			case ch <- message:
+			default:
+				log.Printf("Dropped %s event for user %d: stream is full", event.Type, userID)
+			}
+		}
+	}
+}
+
+// run listens until ctx is cancelled, reconnecting if the connection drops.
+// Events published while it's disconnected are lost, so clients refetch
+// whenever their stream reconnects.
+func (h *Hub) run(ctx context.Context) {
+	for {
+		err := h.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Event listener disconnected, reconnecting in %s: %v", reconnectDelay, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (h *Hub) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, h.databaseURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{Channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Printf("Ignoring malformed event: %v", err)
+			continue
+		}
+		h.dispatch(event)
+	}
+}
@@ -0,0 +1,325 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	// Calendars name zones by TZID; embed the database so lookups work on
+	// hosts without zoneinfo installed.
+	_ "time/tzdata"
+)
+
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Component is a BEGIN/END block such as VCALENDAR, VEVENT or VTODO.
+type Component struct {
+	Name       string
+	Properties []Property
+	Children   []*Component
+}
+
+// Get returns the first property called name, or nil.
+func (c *Component) Get(name string) *Property {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// All returns every property called name, e.g. repeated EXDATE lines.
+func (c *Component) All(name string) []Property {
+	var properties []Property
+	for _, p := range c.Properties {
+		if p.Name == name {
+			properties = append(properties, p)
+		}
+	}
+	return properties
+}
+
+// Text returns the unescaped value of a text property, or "" if it is missing.
+func (c *Component) Text(name string) string {
+	p := c.Get(name)
+	if p == nil {
+		return ""
+	}
+	return unescapeText(p.Value)
+}
+
+// Parse reads an iCalendar document and returns its top-level component,
+// normally VCALENDAR.
+func Parse(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var root *Component
+	var stack []*Component
+	for n, line := range lines {
+		p, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+
+		switch p.Name {
+		case "BEGIN":
+			c := &Component{Name: strings.ToUpper(p.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, c)
+			} else if root == nil {
+				root = c
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(p.Value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", n+1, p.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property outside of a component", n+1)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, p)
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("no calendar found")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1].Name)
+	}
+	return root, nil
+}
+
+// unfold joins continuation lines, which start with a space or tab.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseLine splits NAME;PARAM=VALUE;...:VALUE, honoring quoted parameter
+// values that may contain ':' or ';'.
+func parseLine(line string) (Property, error) {
+	p := Property{Params: map[string]string{}}
+
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return p, fmt.Errorf("malformed content line %q", line)
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := splitUnquoted(head, ';')
+	p.Name = strings.ToUpper(parts[0])
+	p.Value = value
+	for _, param := range parts[1:] {
+		k, v, _ := strings.Cut(param, "=")
+		p.Params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return p, nil
+}
+
+func splitUnquoted(s string, sep rune) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i, r := range s {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == sep && !inQuotes {
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func unescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// Time is a parsed DATE or DATE-TIME value.
+type Time struct {
+	time.Time
+	// AllDay is set for VALUE=DATE, which has no time of day.
+	AllDay bool
+	// Floating is set for local times without a TZID, which RFC 5545 leaves to
+	// the reader's zone. They are interpreted as UTC.
+	Floating bool
+}
+
+// ParseTime parses a DTSTART-like property. Unknown TZIDs are an error so the
+// caller can decide how to report them.
+func ParseTime(p Property) (Time, error) {
+	times, err := ParseTimes(p)
+	if err != nil {
+		return Time{}, err
+	}
+	if len(times) != 1 {
+		return Time{}, fmt.Errorf("%s: expected a single value", p.Name)
+	}
+	return times[0], nil
+}
+
+// ParseTimes parses a property that may hold a comma-separated list of times,
+// such as EXDATE.
+func ParseTimes(p Property) ([]Time, error) {
+	loc := time.UTC
+	if tzid := p.Params["TZID"]; tzid != "" {
+		var err error
+		if loc, err = time.LoadLocation(strings.TrimPrefix(tzid, "/")); err != nil {
+			return nil, fmt.Errorf("%s: unknown time zone %q", p.Name, tzid)
+		}
+	}
+
+	var times []Time
+	for _, value := range strings.Split(p.Value, ",") {
+		var t Time
+		var err error
+		switch {
+		case p.Params["VALUE"] == "DATE" || len(value) == len("20060102"):
+			t.AllDay = true
+			t.Time, err = time.ParseInLocation("20060102", value, loc)
+		case strings.HasSuffix(value, "Z"):
+			t.Time, err = time.Parse("20060102T150405Z", value)
+		default:
+			t.Floating = p.Params["TZID"] == ""
+			t.Time, err = time.ParseInLocation("20060102T150405", value, loc)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid time %q", p.Name, value)
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+// RRule is a parsed recurrence rule. Parts the caller doesn't understand are
+// kept in Other so it can reject them rather than silently misread them.
+type RRule struct {
+	Freq     string
+	Interval int
+	Count    int
+	Until    time.Time
+	ByDay    []string
+	Other    map[string]string
+}
+
+func ParseRRule(value string) (RRule, error) {
+	rule := RRule{Interval: 1, Other: map[string]string{}}
+	for _, part := range strings.Split(value, ";") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return rule, fmt.Errorf("RRULE: malformed part %q", part)
+		}
+		var err error
+		switch strings.ToUpper(k) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(v)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(v)
+			if err == nil && rule.Interval <= 0 {
+				err = errors.New("must be positive")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(v)
+		case "UNTIL":
+			var until Time
+			until, err = ParseTime(Property{Name: "UNTIL", Value: v, Params: map[string]string{}})
+			rule.Until = until.Time
+		case "BYDAY":
+			rule.ByDay = strings.Split(strings.ToUpper(v), ",")
+		case "WKST":
+			// Only affects rules with BYDAY spanning several days.
+		default:
+			rule.Other[strings.ToUpper(k)] = v
+		}
+		if err != nil {
+			return rule, fmt.Errorf("RRULE: invalid %s %q: %v", k, v, err)
+		}
+	}
+	if rule.Freq == "" {
+		return rule, errors.New("RRULE: FREQ is required")
+	}
+	return rule, nil
+}
+
+// Every returns the fixed interval the rule repeats at. Only rules that map
+// onto a constant period are supported: MONTHLY and YEARLY vary in length,
+// and BYxxx parts other than a single BYDAY matching start produce irregular
+// gaps.
+func (r RRule) Every(start time.Time) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"SECONDLY": time.Second,
+		"MINUTELY": time.Minute,
+		"HOURLY":   time.Hour,
+		"DAILY":    24 * time.Hour,
+		"WEEKLY":   7 * 24 * time.Hour,
+	}
+	unit, ok := units[r.Freq]
+	if !ok {
+		return 0, fmt.Errorf("FREQ=%s is not supported", r.Freq)
+	}
+	if len(r.Other) > 0 {
+		return 0, fmt.Errorf("%s is not supported", slices.Sorted(maps.Keys(r.Other))[0])
+	}
+	if len(r.ByDay) > 0 {
+		weekday := strings.ToUpper(start.Weekday().String()[:2])
+		if r.Freq != "WEEKLY" || len(r.ByDay) != 1 || r.ByDay[0] != weekday {
+			return 0, errors.New("BYDAY is only supported for weekly rules on the start day")
+		}
+	}
+	return time.Duration(r.Interval) * unit, nil
+}
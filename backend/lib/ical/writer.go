@@ -0,0 +1,153 @@
+// Package ical reads and writes the subset of iCalendar (RFC 5545) that maps
+// onto reminders.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateTimeFormat = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	URL         string
+	Start       time.Time
+	Stamp       time.Time
+	// Every repeats the event at a fixed interval; zero means it happens once.
+	Every time.Duration
+	// Exclude are occurrences of a repeating event that don't happen.
+	Exclude []time.Time
+	// Alarm adds a display alarm that goes off at Start.
+	Alarm bool
+}
+
+// Write renders the calendar as a VCALENDAR document with CRLF line endings.
+func Write(w io.Writer, cal Calendar) error {
+	bw := bufio.NewWriter(w)
+	lw := &lineWriter{w: bw}
+
+	lw.line("BEGIN:VCALENDAR")
+	lw.line("VERSION:2.0")
+	lw.line("PRODID:-//Uchi//Reminders//EN")
+	lw.line("CALSCALE:GREGORIAN")
+	lw.line("METHOD:PUBLISH")
+	if cal.Name != "" {
+		lw.line("X-WR-CALNAME:" + escapeText(cal.Name))
+	}
+	for _, event := range cal.Events {
+		writeEvent(lw, event)
+	}
+	lw.line("END:VCALENDAR")
+
+	if lw.err != nil {
+		return lw.err
+	}
+	return bw.Flush()
+}
+
+func writeEvent(lw *lineWriter, event Event) {
+	lw.line("BEGIN:VEVENT")
+	lw.line("UID:" + event.UID)
+	lw.line("DTSTAMP:" + formatTime(event.Stamp))
+	lw.line("DTSTART:" + formatTime(event.Start))
+	lw.line("DTEND:" + formatTime(event.Start))
+	if event.Every > 0 {
+		lw.line("RRULE:" + FormatRRule(event.Every))
+		for _, excluded := range event.Exclude {
+			lw.line("EXDATE:" + formatTime(excluded))
+		}
+	}
+	lw.line("SUMMARY:" + escapeText(event.Summary))
+	if event.Description != "" {
+		lw.line("DESCRIPTION:" + escapeText(event.Description))
+	}
+	if event.URL != "" {
+		lw.line("URL:" + event.URL)
+	}
+	if event.Alarm {
+		lw.line("BEGIN:VALARM")
+		lw.line("ACTION:DISPLAY")
+		lw.line("TRIGGER:PT0M")
+		lw.line("DESCRIPTION:" + escapeText(event.Summary))
+		lw.line("END:VALARM")
+	}
+	lw.line("END:VEVENT")
+}
+
+// FormatRRule expresses a fixed interval with the coarsest frequency that
+// divides it evenly, e.g. 2 weeks becomes FREQ=WEEKLY;INTERVAL=2.
+func FormatRRule(every time.Duration) string {
+	units := []struct {
+		freq string
+		size time.Duration
+	}{
+		{"WEEKLY", 7 * 24 * time.Hour},
+		{"DAILY", 24 * time.Hour},
+		{"HOURLY", time.Hour},
+		{"MINUTELY", time.Minute},
+	}
+	for _, unit := range units {
+		if every%unit.size == 0 {
+			return fmt.Sprintf("FREQ=%s;INTERVAL=%d", unit.freq, every/unit.size)
+		}
+	}
+	return fmt.Sprintf("FREQ=SECONDLY;INTERVAL=%d", every/time.Second)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat)
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// lineWriter folds content lines longer than 75 octets, never splitting a
+// UTF-8 sequence, and remembers the first write error.
+type lineWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (lw *lineWriter) line(s string) {
+	if lw.err != nil {
+		return
+	}
+	var b strings.Builder
+	width := maxLineOctets
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts towards the limit.
+		width = maxLineOctets - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	_, lw.err = io.WriteString(lw.w, b.String())
+}
@@ -0,0 +1,20 @@
+package resend
+
+import (
+	"reminder-app/config"
+	"reminder-app/lib/mail"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("mail",
+	fx.Provide(fx.Annotate(
+		func(cfg *config.Config) *ResendSender {
+			return &ResendSender{
+				ApiKey: cfg.Resend.ApiKey,
+				Domain: cfg.Resend.Domain,
+			}
+		},
+		fx.As(new(mail.Sender), new(mail.Receiver)),
+	)),
+)
@@ -1,13 +1,16 @@
 package resend
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"reminder-app/lib/mail"
 
 	resendsdk "github.com/resend/resend-go/v2"
 )
 
 var _ mail.Sender = &ResendSender{}
+var _ mail.Receiver = &ResendSender{}
 
 type ResendSender struct {
 	ApiKey string
@@ -15,10 +18,15 @@ type ResendSender struct {
 }
 
 func (s *ResendSender) Send(to string, subject string, body string) error {
+	return s.SendWithReplyTo(to, "", subject, body)
+}
+
+func (s *ResendSender) SendWithReplyTo(to string, replyTo string, subject string, body string) error {
 	client := resendsdk.NewClient(s.ApiKey)
 	params := &resendsdk.SendEmailRequest{
 		From:    fmt.Sprintf("UchiBot <reminder@%s>", s.Domain),
 		To:      []string{to},
+		ReplyTo: replyTo,
 		Html:    body,
 		Subject: subject,
 	}
@@ -29,3 +37,25 @@ func (s *ResendSender) Send(to string, subject string, body string) error {
 
 	return nil
 }
+
+type receivedEmail struct {
+	From    string   `json:"from"`
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Text    string   `json:"text"`
+}
+
+// GetReceivedEmail fetches the content of an email.received webhook's email,
+// which the webhook leaves out.
+func (s *ResendSender) GetReceivedEmail(ctx context.Context, id string) (*mail.InboundEmail, error) {
+	client := resendsdk.NewClient(s.ApiKey)
+	req, err := client.NewRequest(ctx, http.MethodGet, "emails/receiving/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+	var email receivedEmail
+	if _, err := client.Perform(req, &email); err != nil {
+		return nil, err
+	}
+	return &mail.InboundEmail{From: email.From, To: email.To, Subject: email.Subject, Text: email.Text}, nil
+}
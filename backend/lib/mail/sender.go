@@ -1,5 +1,23 @@
 package mail
 
+import "context"
+
 type Sender interface {
 	Send(to string, subject string, body string) error
+	// SendWithReplyTo sends mail whose replies go to replyTo.
+	SendWithReplyTo(to string, replyTo string, subject string, body string) error
+}
+
+// InboundEmail is a message received on the inbound domain.
+type InboundEmail struct {
+	From    string
+	To      []string
+	Subject string
+	// Text is the plain text body, which replies quote the original under.
+	Text string
+}
+
+// Receiver fetches received mail that a webhook announced by ID.
+type Receiver interface {
+	GetReceivedEmail(ctx context.Context, id string) (*InboundEmail, error)
 }
@@ -0,0 +1,47 @@
+// Package mergepatch applies JSON Merge Patches (RFC 7396).
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var ErrNotObject = errors.New("merge patch must be a JSON object")
+
+// Apply merges patch into doc. Objects merge key by key, null removes a key,
+// and anything else replaces the target value outright. Patches that aren't
+// objects are rejected since they would replace the whole resource.
+func Apply(doc []byte, patch []byte) ([]byte, error) {
+	var patchValue any
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, err
+	}
+	if _, ok := patchValue.(map[string]any); !ok {
+		return nil, ErrNotObject
+	}
+
+	var docValue any
+	if err := json.Unmarshal(doc, &docValue); err != nil {
+		return nil, err
+	}
+	return json.Marshal(merge(docValue, patchValue))
+}
+
+func merge(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = merge(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
@@ -0,0 +1,87 @@
+package reply
+
+import (
+	"errors"
+	"net/mail"
+	"reminder-app/lib/signing"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Replies to a reminder email go to a delivery's address, and mail to a
+	// user's inbox address becomes a new reminder.
+	AddressDelivery = "reply"
+	AddressInbox    = "inbox"
+
+	signingPurposeDelivery = "email-reply"
+	signingPurposeInbox    = "email-inbox"
+)
+
+var ErrUnknownAddress = errors.New("not a reply or inbox address")
+
+// Addresses mints and reads the signed email addresses, on the inbound mail
+// domain, that replies and new reminders are sent to. Their local parts fit
+// in the 64 characters email allows.
+type Addresses struct {
+	signer *signing.Signer
+	domain string
+}
+
+// NewAddresses returns nil when there's no inbound domain, and so no way
+// to receive mail, or no signer to sign the addresses with.
+func NewAddresses(signer *signing.Signer, domain string) *Addresses {
+	if signer == nil || domain == "" {
+		return nil
+	}
+	return &Addresses{signer: signer, domain: strings.ToLower(domain)}
+}
+
+// ForDelivery is the reply-to address of the email a delivery was sent as.
+func (a *Addresses) ForDelivery(deliveryID int64) string {
+	return a.address(AddressDelivery, signingPurposeDelivery, deliveryID)
+}
+
+// ForUser is the address a user can email new reminders to. It never
+// changes, so mail to it is only accepted from the user's own addresses.
+func (a *Addresses) ForUser(userID int64) string {
+	return a.address(AddressInbox, signingPurposeInbox, userID)
+}
+
+func (a *Addresses) address(kind string, purpose string, id int64) string {
+	return kind + "-" + a.signer.Sign(purpose, strconv.FormatInt(id, 10), time.Time{}) + "@" + a.domain
+}
+
+// Parse reads an address made by ForDelivery or ForUser, which may have a
+// display name, and returns its kind and the ID it was made for.
+func (a *Addresses) Parse(address string) (kind string, id int64, err error) {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", 0, ErrUnknownAddress
+	}
+	local, domain, _ := strings.Cut(strings.ToLower(parsed.Address), "@")
+	kind, token, _ := strings.Cut(local, "-")
+	if domain != a.domain {
+		return "", 0, ErrUnknownAddress
+	}
+
+	var purpose string
+	switch kind {
+	case AddressDelivery:
+		purpose = signingPurposeDelivery
+	case AddressInbox:
+		purpose = signingPurposeInbox
+	default:
+		return "", 0, ErrUnknownAddress
+	}
+	subject, err := a.signer.Verify(purpose, token)
+	if err != nil {
+		return "", 0, err
+	}
+	id, err = strconv.ParseInt(subject, 10, 64)
+	if err != nil {
+		return "", 0, ErrUnknownAddress
+	}
+	return kind, id, nil
+}
@@ -0,0 +1,20 @@
+package reply
+
+import (
+	"reminder-app/config"
+	"reminder-app/lib/signing"
+
+	"go.uber.org/fx"
+)
+
+// Module provides nil *Addresses unless both the inbound mail domain and the
+// secret its webhook is verified with are configured. The addresses are only
+// as strong as the signing secret, which signing.Module checks at startup.
+var Module = fx.Module("reply",
+	fx.Provide(func(cfg *config.Config, signer *signing.Signer) *Addresses {
+		if cfg.Resend.InboundWebhookSecretKey == "" {
+			return nil
+		}
+		return NewAddresses(signer, cfg.Resend.InboundDomain)
+	}),
+)
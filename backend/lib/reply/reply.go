@@ -0,0 +1,147 @@
+// Package reply parses the commands recipients answer reminders with, such as
+// "done" or "snooze 1h", whether typed or sent by a button.
+package reply
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Done acknowledges a delivery, which stops any snoozes of it.
+	Done = "done"
+	// Snooze delivers the reminder again after a while.
+	Snooze = "snooze"
+
+	DefaultSnooze = time.Hour
+	MaxSnooze     = 7 * 24 * time.Hour
+)
+
+var ErrUnknownCommand = errors.New(`unknown command, reply "done" or "snooze" followed by how long, such as "snooze 1h"`)
+
+type Command struct {
+	Action string
+	// Snooze is how long a snooze lasts.
+	Snooze time.Duration
+}
+
+// String formats c so that Parse reads it back.
+func (c Command) String() string {
+	if c.Action == Snooze {
+		return Snooze + " " + FormatDuration(c.Snooze)
+	}
+	return c.Action
+}
+
+// Parse reads a command, ignoring case. Snoozes take a duration such as 30m,
+// 1h, 2d or "2 hours"; a bare number is minutes, and none is DefaultSnooze.
+func Parse(text string) (Command, error) {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 {
+		return Command{}, ErrUnknownCommand
+	}
+
+	switch fields[0] {
+	case Done:
+		return Command{Action: Done}, nil
+	case Snooze:
+		if len(fields) == 1 {
+			return Command{Action: Snooze, Snooze: DefaultSnooze}, nil
+		}
+		d, err := ParseDuration(strings.Join(fields[1:], " "))
+		if err != nil {
+			return Command{}, err
+		}
+		if d > MaxSnooze {
+			return Command{}, fmt.Errorf("reminders can be snoozed for up to %s", FormatDuration(MaxSnooze))
+		}
+		return Command{Action: Snooze, Snooze: d}, nil
+	}
+	return Command{}, ErrUnknownCommand
+}
+
+// units spells out the unit words ParseDuration accepts, longest first so
+// that "minutes" isn't read as "m" followed by "inutes".
+var units = []struct {
+	word string
+	unit time.Duration
+}{
+	{"minutes", time.Minute}, {"minute", time.Minute}, {"mins", time.Minute}, {"min", time.Minute}, {"m", time.Minute},
+	{"hours", time.Hour}, {"hour", time.Hour}, {"hrs", time.Hour}, {"hr", time.Hour}, {"h", time.Hour},
+	{"days", 24 * time.Hour}, {"day", 24 * time.Hour}, {"d", 24 * time.Hour},
+}
+
+// ParseDuration reads a duration such as 30m, 1h30m, 2d or "2 hours". A bare
+// number is minutes.
+func ParseDuration(text string) (time.Duration, error) {
+	invalid := fmt.Errorf("%q isn't a duration, try something like 30m, 1h or 2d", text)
+	s := strings.ReplaceAll(strings.ToLower(text), " ", "")
+
+	var total time.Duration
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+		if i == 0 {
+			return 0, invalid
+		}
+		if i < 0 {
+			i = len(s)
+		}
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, invalid
+		}
+		s = s[i:]
+
+		unit := time.Minute
+		if s != "" {
+			matched := false
+			for _, u := range units {
+				if strings.HasPrefix(s, u.word) {
+					unit, s, matched = u.unit, s[len(u.word):], true
+					break
+				}
+			}
+			if !matched {
+				return 0, invalid
+			}
+		}
+		total += time.Duration(n) * unit
+	}
+
+	if total <= 0 {
+		return 0, invalid
+	}
+	return total, nil
+}
+
+// FormatDuration writes d in the largest unit it divides into, such as 15m,
+// 2h or 1d.
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return strconv.FormatInt(int64(d/(24*time.Hour)), 10) + "d"
+	case d%time.Hour == 0:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	}
+	return strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+}
+
+// ButtonData encodes cmd for a button on deliveryID, as channel.Action data.
+// It fits in Telegram's 64 bytes of callback data.
+func ButtonData(deliveryID int64, cmd Command) string {
+	return strconv.FormatInt(deliveryID, 10) + " " + cmd.String()
+}
+
+// ParseButtonData reads data made by ButtonData.
+func ParseButtonData(data string) (int64, Command, error) {
+	id, text, _ := strings.Cut(data, " ")
+	deliveryID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, Command{}, fmt.Errorf("invalid button data %q", data)
+	}
+	cmd, err := Parse(text)
+	return deliveryID, cmd, err
+}
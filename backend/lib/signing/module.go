@@ -0,0 +1,13 @@
+package signing
+
+import (
+	"reminder-app/config"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("signing",
+	fx.Provide(func(cfg *config.Config) (*Signer, error) {
+		return New(cfg.App.SigningSecret)
+	}),
+)
@@ -0,0 +1,23 @@
+package webpush
+
+import (
+	"log"
+	"reminder-app/config"
+
+	"go.uber.org/fx"
+)
+
+// Module provides a nil *Sender when no VAPID key is configured.
+var Module = fx.Module("webpush",
+	fx.Provide(func(cfg *config.Config) (*Sender, error) {
+		if cfg.Push.VAPIDPrivateKey == "" {
+			log.Println("PUSH_VAPID_PRIVATE_KEY is not set, push notifications are disabled")
+			return nil, nil
+		}
+		key, err := ParseVAPIDKey(cfg.Push.VAPIDPrivateKey, cfg.Push.VAPIDSubject)
+		if err != nil {
+			return nil, err
+		}
+		return NewSender(key), nil
+	}),
+)
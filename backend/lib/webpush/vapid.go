@@ -0,0 +1,111 @@
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+)
+
+// vapidTokenTTL is how long each VAPID token is valid. Push services reject
+// tokens that expire more than 24 hours out.
+const vapidTokenTTL = 12 * time.Hour
+
+// VAPIDKey identifies this server to push services, so only it can send to
+// the subscriptions browsers made with its public key.
+type VAPIDKey struct {
+	private *ecdsa.PrivateKey
+	public  []byte
+	// subject is a mailto: or https: URL push services can reach the
+	// operator at.
+	subject string
+}
+
+// GenerateVAPIDKey returns a new key pair, each base64url encoded: the public
+// key as an uncompressed P-256 point and the private key as its scalar.
+func GenerateVAPIDKey() (publicKey string, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// ParseVAPIDKey reads a private key as GenerateVAPIDKey encodes it.
+func ParseVAPIDKey(privateKey string, subject string) (*VAPIDKey, error) {
+	raw, err := decode(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	if subject == "" {
+		return nil, errors.New("a VAPID subject is required")
+	}
+
+	public := key.PublicKey().Bytes()
+	return &VAPIDKey{
+		private: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(public[1:33]),
+				Y:     new(big.Int).SetBytes(public[33:]),
+			},
+			D: new(big.Int).SetBytes(raw),
+		},
+		public:  public,
+		subject: subject,
+	}, nil
+}
+
+// PublicKey returns the key base64url encoded, as browsers take it.
+func (k *VAPIDKey) PublicKey() string {
+	return base64.RawURLEncoding.EncodeToString(k.public)
+}
+
+// authorization returns the Authorization header for a request to endpoint:
+// a JWT for the push service's origin, signed with ES256.
+func (k *VAPIDKey) authorization(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{"typ": "JWT", "alg": "ES256"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(vapidTokenTTL).Unix(),
+		"sub": k.subject,
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, k.private, digest[:])
+	if err != nil {
+		return "", err
+	}
+	// JWS wants the fixed-width r || s, not ASN.1.
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return fmt.Sprintf("vapid t=%s, k=%s", token, k.PublicKey()), nil
+}
@@ -0,0 +1,234 @@
+// Package webpush sends Web Push messages: payloads encrypted for the browser
+// as in RFC 8291, from a server identified by its VAPID key as in RFC 8292.
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrGone is returned for subscriptions the push service no longer accepts,
+// usually because the user revoked permission or the browser unsubscribed.
+// They should be deleted.
+var ErrGone = errors.New("push subscription has expired or been unsubscribed")
+
+const (
+	// recordSize is the single aes128gcm record a message is sent in.
+	recordSize = 4096
+	// MaxPayload is the longest payload that fits in one record, after the
+	// header, the padding delimiter and the authentication tag.
+	MaxPayload = recordSize - 86 - 1 - 16
+
+	// ttl is how long the push service keeps a message for an offline browser.
+	ttl = 24 * time.Hour
+)
+
+// Subscription is a browser's PushSubscription, as serialized by its toJSON.
+type Subscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// ParseSubscription decodes and checks a subscription stored as JSON.
+func ParseSubscription(value string) (*Subscription, error) {
+	var sub Subscription
+	if err := json.Unmarshal([]byte(value), &sub); err != nil {
+		return nil, fmt.Errorf("invalid push subscription: %w", err)
+	}
+	if err := sub.Validate(); err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// Validate checks that messages can be encrypted for and sent to sub.
+func (sub *Subscription) Validate() error {
+	endpoint, err := url.Parse(sub.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return errors.New("push subscription endpoint must be an https URL")
+	}
+	if _, err := sub.publicKey(); err != nil {
+		return err
+	}
+	if _, err := sub.authSecret(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sub *Subscription) publicKey() (*ecdh.PublicKey, error) {
+	raw, err := decode(sub.Keys.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	key, err := ecdh.P256().NewPublicKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	return key, nil
+}
+
+func (sub *Subscription) authSecret() ([]byte, error) {
+	secret, err := decode(sub.Keys.Auth)
+	if err != nil || len(secret) != 16 {
+		return nil, errors.New("invalid auth secret: must be 16 bytes")
+	}
+	return secret, nil
+}
+
+// Sender delivers messages to push services.
+type Sender struct {
+	vapid  *VAPIDKey
+	client *http.Client
+}
+
+func NewSender(vapid *VAPIDKey) *Sender {
+	return &Sender{vapid: vapid, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// PublicKey is the applicationServerKey browsers subscribe with.
+func (s *Sender) PublicKey() string {
+	return s.vapid.PublicKey()
+}
+
+// Send encrypts payload for sub and hands it to its push service. It returns
+// ErrGone if the subscription should be deleted.
+func (s *Sender) Send(ctx context.Context, sub *Subscription, payload []byte) error {
+	if len(payload) > MaxPayload {
+		return fmt.Errorf("push payload is %d bytes, over the limit of %d", len(payload), MaxPayload)
+	}
+	body, err := encrypt(sub, payload)
+	if err != nil {
+		return err
+	}
+	authorization, err := s.vapid.authorization(sub.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+	req.Header.Set("Urgency", "high")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusGone || resp.StatusCode == http.StatusNotFound:
+		return ErrGone
+	case resp.StatusCode >= 300:
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("push service responded %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+// encrypt seals payload for sub with a fresh server key and salt.
+func encrypt(sub *Subscription, payload []byte) ([]byte, error) {
+	uaPublic, err := sub.publicKey()
+	if err != nil {
+		return nil, err
+	}
+	authSecret, err := sub.authSecret()
+	if err != nil {
+		return nil, err
+	}
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return seal(uaPublic, authSecret, asPrivate, salt, payload)
+}
+
+// seal encrypts payload in a single aes128gcm record (RFC 8188), keyed as in
+// RFC 8291 from the browser's key and auth secret and the server's key.
+func seal(uaPublic *ecdh.PublicKey, authSecret []byte, asPrivate *ecdh.PrivateKey, salt []byte, payload []byte) ([]byte, error) {
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	// Mix the auth secret and both public keys into the shared secret.
+	prkKey, err := hkdf.Extract(sha256.New, ecdhSecret, authSecret)
+	if err != nil {
+		return nil, err
+	}
+	keyInfo := "WebPush: info\x00" + string(uaPublic.Bytes()) + string(asPublic)
+	ikm, err := hkdf.Expand(sha256.New, prkKey, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// The header carries the salt and the server's public key, which the
+	// browser needs to derive the same keys.
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	// 0x02 marks the last, and only, record.
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+func decode(s string) ([]byte, error) {
+	// Browsers send unpadded base64url, but some libraries pad it.
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
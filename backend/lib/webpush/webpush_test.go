@@ -0,0 +1,73 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// The example in RFC 8291 Appendix A.
+func TestSeal(t *testing.T) {
+	asPrivateKey := mustDecode(t, "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw")
+	uaPublicKey := mustDecode(t, "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4")
+	authSecret := mustDecode(t, "BTBZMqHH6r4Tts7J_aSIgg")
+	salt := mustDecode(t, "DGv6ra1nlYgDCS1FRnbzlw")
+	want := mustDecode(t, "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN")
+
+	asPrivate, err := ecdh.P256().NewPrivateKey(asPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := seal(uaPublic, authSecret, asPrivate, salt, []byte("When I grow up, I want to be a watermelon"))
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got\n%x\nwant\n%x", got, want)
+	}
+}
+
+func TestSendGone(t *testing.T) {
+	_, privateKey, err := GenerateVAPIDKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vapid, err := ParseVAPIDKey(privateKey, "mailto:admin@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, status := range []int{http.StatusNotFound, http.StatusGone} {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		sender := NewSender(vapid)
+		sender.client = server.Client()
+
+		sub := &Subscription{Endpoint: server.URL + "/push/abc"}
+		sub.Keys.P256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+		sub.Keys.Auth = "BTBZMqHH6r4Tts7J_aSIgg"
+		if err := sender.Send(context.Background(), sub, []byte("{}")); !errors.Is(err, ErrGone) {
+			t.Errorf("status %d: got %v, want ErrGone", status, err)
+		}
+		server.Close()
+	}
+}
+
+func mustDecode(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := decode(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
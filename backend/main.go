@@ -8,6 +8,12 @@ import (
 	"reminder-app/controller"
 	gormmodule "reminder-app/db/gorm"
 	"reminder-app/handler"
+	"reminder-app/lib/channel/telegram"
+	"reminder-app/lib/events"
+	"reminder-app/lib/mail/resend"
+	"reminder-app/lib/reply"
+	"reminder-app/lib/signing"
+	"reminder-app/lib/webpush"
 	"reminder-app/river/riverclient"
 	"reminder-app/workers"
 
@@ -31,6 +37,11 @@ func StartRiver(db *gorm.DB, riverClient *river.Client[pgx.Tx]) {
 	log.Println("River client started successfully")
 }
 
+func StartEvents(hub *events.Hub) {
+	log.Println("Starting event listener...")
+	hub.Start(context.Background())
+}
+
 func StartReminderService(cfg *config.Config, httpHandler *handler.Handler) {
 	log.Printf("Starting reminder service on port %s\n", cfg.Port)
 
@@ -47,11 +58,18 @@ func main() {
 	fxApp := fx.New(
 		config.Module,
 		gormmodule.Module,
+		resend.Module,
+		signing.Module,
+		reply.Module,
+		events.Module,
+		webpush.Module,
+		telegram.Module,
 		riverclient.Module,
 		workers.Module,
 		controller.Module,
 		handler.Module,
 		fx.Invoke(StartRiver),
+		fx.Invoke(StartEvents),
 		fx.Invoke(StartReminderService),
 	)
 	fxApp.Run()
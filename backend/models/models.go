@@ -17,23 +17,184 @@ type User struct {
 	BaseModel `tstype:",extends"`
 	Name      string `json:"name" gorm:"not null"`
 	ClerkID   string `json:"clerk_id" gorm:"not null;unique"`
+	// Email is the primary email address Clerk verified, if any. Unlike email
+	// contact methods, which anyone can add, it can address invitations.
+	Email *string `json:"email" gorm:"index"`
 }
 
+// Contact types mirror the contact_type enum in the database.
+const (
+	ContactTypePhone = "phone"
+	ContactTypeEmail = "email"
+	// ContactTypePush is a browser's Web Push subscription, stored as the JSON
+	// of its PushSubscription.
+	ContactTypePush = "push"
+	// Slack and Discord contact methods are incoming webhook URLs.
+	ContactTypeSlack   = "slack"
+	ContactTypeDiscord = "discord"
+	// ContactTypeNtfy is an ntfy topic, or a topic URL on another server.
+	ContactTypeNtfy = "ntfy"
+	// ContactTypeGotify is a Gotify server URL with an application token.
+	ContactTypeGotify = "gotify"
+	// ContactTypeTelegram is the ID of a chat with the bot, added by sending
+	// it /start with a link code.
+	ContactTypeTelegram = "telegram"
+)
+
+var ContactTypes = []string{ContactTypePhone, ContactTypeEmail, ContactTypePush, ContactTypeSlack, ContactTypeDiscord, ContactTypeNtfy, ContactTypeGotify, ContactTypeTelegram}
+
 type ContactMethod struct {
 	BaseModel   `tstype:",extends"`
 	UserID      int64  `json:"user_id" gorm:"not null"`
 	Type        string `json:"type" gorm:"not null;type:contact_type"`
 	Value       string `json:"value" gorm:"not null"`
 	Description string `json:"description"`
+	// DisabledAt is set when the recipient opts out, such as by texting STOP
+	// to a phone number. Nothing is sent until they opt back in.
+	DisabledAt *time.Time `json:"disabled_at"`
 }
 
 type Reminder struct {
 	BaseModel       `tstype:",extends"`
 	UserID          int64     `json:"user_id" gorm:"not null"`
+	HouseholdID     *int64    `json:"household_id" gorm:"index"`
 	RiverJobID      int       `json:"river_job_id"`
 	ContactMethodID int64     `json:"contact_method_id" gorm:"not null"`
 	Body            string    `json:"body" gorm:"not null"`
 	StartTime       time.Time `json:"start_time" gorm:"not null"`
 	IsRepeating     bool      `json:"is_repeating" gorm:"not null;default:false"`
 	PeriodMinutes   int64     `json:"period_minutes" gorm:"not null;default:0"`
+	Tags            []string  `json:"tags" gorm:"type:jsonb;not null;default:'[]';serializer:json"`
+	// ExcludedTimes are occurrences of a repeating reminder that are skipped,
+	// such as EXDATEs of an imported calendar event.
+	ExcludedTimes []time.Time `json:"excluded_times" gorm:"type:jsonb;not null;default:'[]';serializer:json"`
+	// PausedAt is set while the reminder is paused. A pause with PausedUntil
+	// ends on its own at that time; otherwise it lasts until resumed.
+	PausedAt    *time.Time `json:"paused_at"`
+	PausedUntil *time.Time `json:"paused_until"`
+	// RotationIndex is the position of the current assignee of a rotating reminder.
+	RotationIndex int            `json:"rotation_index" gorm:"not null;default:0"`
+	RotationSlots []RotationSlot `json:"rotation_slots" gorm:"foreignKey:ReminderID"`
+}
+
+// RotationSlot is one assignee in a repeating reminder's rotation. While a
+// reminder rotates, its ContactMethodID mirrors the current slot.
+type RotationSlot struct {
+	BaseModel       `tstype:",extends"`
+	ReminderID      int64          `json:"reminder_id" gorm:"not null;index"`
+	Position        int            `json:"position" gorm:"not null"`
+	ContactMethodID int64          `json:"contact_method_id" gorm:"not null"`
+	ContactMethod   *ContactMethod `json:"contact_method" gorm:"foreignKey:ContactMethodID"`
+}
+
+type APIToken struct {
+	BaseModel  `tstype:",extends"`
+	UserID     int64      `json:"user_id" gorm:"not null;index"`
+	Name       string     `json:"name" gorm:"not null"`
+	Prefix     string     `json:"prefix" gorm:"not null"`
+	TokenHash  string     `json:"-" gorm:"not null;uniqueIndex"`
+	Scopes     []string   `json:"scopes" gorm:"not null;serializer:json"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+const (
+	HouseholdRoleOwner  = "owner"
+	HouseholdRoleAdmin  = "admin"
+	HouseholdRoleMember = "member"
+)
+
+type Household struct {
+	BaseModel `tstype:",extends"`
+	Name      string `json:"name" gorm:"not null"`
+}
+
+type HouseholdMember struct {
+	BaseModel   `tstype:",extends"`
+	HouseholdID int64  `json:"household_id" gorm:"not null;uniqueIndex:idx_household_members_household_user"`
+	UserID      int64  `json:"user_id" gorm:"not null;uniqueIndex:idx_household_members_household_user;index"`
+	Role        string `json:"role" gorm:"not null"`
+}
+
+const (
+	InvitationKindHousehold = "household"
+	InvitationKindReminder  = "reminder"
+)
+
+// Invitation invites someone by email to join a household or to subscribe to
+// a reminder. InviteeUserID is filled in once the email belongs to a user.
+type Invitation struct {
+	BaseModel       `tstype:",extends"`
+	Kind            string     `json:"kind" gorm:"not null"`
+	HouseholdID     *int64     `json:"household_id" gorm:"index"`
+	ReminderID      *int64     `json:"reminder_id" gorm:"index"`
+	Role            string     `json:"role"`
+	Email           string     `json:"email" gorm:"not null;index"`
+	InvitedByUserID int64      `json:"invited_by_user_id" gorm:"not null"`
+	InviteeUserID   *int64     `json:"invitee_user_id" gorm:"index"`
+	ExpiresAt       time.Time  `json:"expires_at" gorm:"not null"`
+	AcceptedAt      *time.Time `json:"accepted_at"`
+}
+
+// ReminderSubscription delivers someone else's reminder to one of the
+// subscriber's own contact methods.
+type ReminderSubscription struct {
+	BaseModel       `tstype:",extends"`
+	ReminderID      int64 `json:"reminder_id" gorm:"not null;uniqueIndex:idx_reminder_subscriptions_reminder_user"`
+	UserID          int64 `json:"user_id" gorm:"not null;uniqueIndex:idx_reminder_subscriptions_reminder_user"`
+	ContactMethodID int64 `json:"contact_method_id" gorm:"not null"`
+}
+
+// CalendarFeed is a user's iCalendar subscription. Only a hash of the feed
+// token is stored, so rotating the token revokes every subscribed calendar.
+type CalendarFeed struct {
+	BaseModel     `tstype:",extends"`
+	UserID        int64      `json:"user_id" gorm:"not null;uniqueIndex"`
+	Prefix        string     `json:"prefix" gorm:"not null"`
+	TokenHash     string     `json:"-" gorm:"not null;uniqueIndex"`
+	LastFetchedAt *time.Time `json:"last_fetched_at"`
+}
+
+const (
+	DeliveryStatusSent   = "sent"
+	DeliveryStatusFailed = "failed"
+)
+
+// Delivery records each attempt to send a reminder to a contact method.
+type Delivery struct {
+	BaseModel       `tstype:",extends"`
+	ReminderID      int64  `json:"reminder_id" gorm:"not null;index"`
+	ContactMethodID int64  `json:"contact_method_id" gorm:"not null;index"`
+	Status          string `json:"status" gorm:"not null"`
+	Error           string `json:"error"`
+	// AcknowledgedAt is set when the recipient replies "done", and stops
+	// snoozes of the delivery from being sent.
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+}
+
+// TelegramLinkCode is a single-use code a user sends the Telegram bot to link
+// the chat as a contact method. Only its hash is stored.
+type TelegramLinkCode struct {
+	BaseModel `tstype:",extends"`
+	UserID    int64     `json:"user_id" gorm:"not null;index"`
+	CodeHash  string    `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+}
+
+const (
+	DataExportStatusPending = "pending"
+	DataExportStatusReady   = "ready"
+	DataExportStatusFailed  = "failed"
+)
+
+// DataExport is a "download my data" request. The archive is kept in the
+// database until ExpiresAt.
+type DataExport struct {
+	BaseModel   `tstype:",extends"`
+	UserID      int64      `json:"user_id" gorm:"not null;index"`
+	Status      string     `json:"status" gorm:"not null"`
+	Archive     []byte     `json:"-"`
+	Error       string     `json:"error"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reminder-app/config"
+	"reminder-app/workers"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -30,6 +31,9 @@ func New(p Params) (*river.Client[pgx.Tx], error) {
 			river.QueueDefault: {MaxWorkers: 10},
 		},
 		Workers: p.Workers,
+		PeriodicJobs: []*river.PeriodicJob{
+			workers.NewPurgeTrashJob(),
+		},
 	}
 
 	riverClient, err := river.NewClient(riverpgxv5.New(pgxPool), config)
@@ -0,0 +1,137 @@
+// Package rivertx lets River jobs take part in gorm transactions. River's
+// database/sql driver can't insert jobs, so the transaction is begun on a
+// connection of its own and River is given a pgx.Tx that runs on the same
+// connection.
+package rivertx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+var errOuterTx = errors.New("the transaction is committed or rolled back by gorm")
+
+// Tx is the River side of a transaction begun by Transaction.
+type Tx struct {
+	conn *sql.Conn
+}
+
+// Transaction runs fn in a gorm transaction, with a Tx for inserting River
+// jobs in the same transaction.
+func Transaction(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB, riverTx *Tx) error) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	session := db.Session(&gorm.Session{Context: ctx})
+	session.Statement.ConnPool = conn
+	return session.Transaction(func(tx *gorm.DB) error {
+		return fn(tx, &Tx{conn: conn})
+	})
+}
+
+// Run calls fn with the transaction as a pgx.Tx for River's Tx methods, such
+// as InsertTx. The pgx.Tx can't be used once fn returns, and gorm can't be used
+// until it does.
+func (t *Tx) Run(fn func(tx pgx.Tx) error) error {
+	return t.conn.Raw(func(driverConn any) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("rivertx: unexpected driver connection %T", driverConn)
+		}
+		return fn(&connTx{conn: stdlibConn.Conn()})
+	})
+}
+
+// connTx is a pgx.Tx for a transaction already in progress on conn. River
+// wraps its own work in nested transactions, which are savepoints.
+type connTx struct {
+	conn *pgx.Conn
+	// depth is 0 for the transaction gorm began, which only gorm can end.
+	depth  int
+	closed bool
+}
+
+var _ pgx.Tx = (*connTx)(nil)
+
+func (t *connTx) savepoint() string {
+	return fmt.Sprintf("rivertx_%d", t.depth)
+}
+
+func (t *connTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	if t.closed {
+		return nil, pgx.ErrTxClosed
+	}
+	nested := &connTx{conn: t.conn, depth: t.depth + 1}
+	if _, err := t.conn.Exec(ctx, "SAVEPOINT "+nested.savepoint()); err != nil {
+		return nil, err
+	}
+	return nested, nil
+}
+
+func (t *connTx) Commit(ctx context.Context) error {
+	return t.end(ctx, "RELEASE SAVEPOINT ")
+}
+
+func (t *connTx) Rollback(ctx context.Context) error {
+	return t.end(ctx, "ROLLBACK TO SAVEPOINT ")
+}
+
+func (t *connTx) end(ctx context.Context, statement string) error {
+	if t.depth == 0 {
+		return errOuterTx
+	}
+	if t.closed {
+		return pgx.ErrTxClosed
+	}
+	t.closed = true
+	_, err := t.conn.Exec(ctx, statement+t.savepoint())
+	return err
+}
+
+func (t *connTx) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return t.conn.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+func (t *connTx) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return t.conn.SendBatch(ctx, b)
+}
+
+// LargeObjects isn't supported, as pgx only builds them for its own
+// transactions.
+func (t *connTx) LargeObjects() pgx.LargeObjects {
+	return pgx.LargeObjects{}
+}
+
+func (t *connTx) Prepare(ctx context.Context, name string, sql string) (*pgconn.StatementDescription, error) {
+	return t.conn.Prepare(ctx, name, sql)
+}
+
+func (t *connTx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return t.conn.Exec(ctx, sql, arguments...)
+}
+
+func (t *connTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return t.conn.Query(ctx, sql, args...)
+}
+
+func (t *connTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return t.conn.QueryRow(ctx, sql, args...)
+}
+
+func (t *connTx) Conn() *pgx.Conn {
+	return t.conn
+}
@@ -0,0 +1,53 @@
+package workers
+
+import (
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/events"
+	"reminder-app/models"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PublishReminderEvent tells everyone who can see the reminder that it
+// changed: its owner, its household and its subscribers. Pass the reminder as
+// it was too when an update moves it between households, so both households
+// hear about it. Publish it in the transaction making the change, so it's only
+// sent if the change commits.
+func PublishReminderEvent(tx *gorm.DB, eventType string, reminder models.Reminder, previous ...models.Reminder) error {
+	var householdIDs []int64
+	userIDs := []int64{reminder.UserID}
+	for _, r := range append([]models.Reminder{reminder}, previous...) {
+		if r.HouseholdID != nil {
+			householdIDs = append(householdIDs, *r.HouseholdID)
+		}
+	}
+
+	if len(householdIDs) > 0 {
+		var members []int64
+		err := tx.Model(&models.HouseholdMember{}).
+			Where("household_id IN ?", householdIDs).
+			Pluck("user_id", &members).Error
+		if err != nil {
+			return err
+		}
+		userIDs = append(userIDs, members...)
+	}
+
+	var subscribers []int64
+	err := tx.Model(&models.ReminderSubscription{}).
+		Where("reminder_id = ?", reminder.ID).
+		Pluck("user_id", &subscribers).Error
+	if err != nil {
+		return err
+	}
+	userIDs = append(userIDs, subscribers...)
+
+	slices.Sort(userIDs)
+	return events.Publish(tx, slices.Compact(userIDs), eventType, protocol.ReminderEvent{
+		Type:       eventType,
+		ReminderID: int64(reminder.ID),
+		At:         time.Now(),
+	})
+}
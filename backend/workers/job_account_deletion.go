@@ -0,0 +1,61 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reminder-app/lib/auth"
+	"reminder-app/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"gorm.io/gorm"
+)
+
+type AccountDeletionJobArgs struct {
+	UserID int64 `json:"user_id"`
+}
+
+func (AccountDeletionJobArgs) Kind() string { return "account_deletion" }
+
+// AccountDeletionWorker permanently deletes an account. The user row is
+// soft-deleted when deletion is requested, which already locks them out.
+type AccountDeletionWorker struct {
+	river.WorkerDefaults[AccountDeletionJobArgs]
+	GormDB      *gorm.DB
+	UserDeleter auth.UserDeleter
+}
+
+func (w *AccountDeletionWorker) Work(ctx context.Context, job *river.Job[AccountDeletionJobArgs]) error {
+	var user models.User
+	err := w.GormDB.Unscoped().Where("id = ?", job.Args.UserID).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Purged by an earlier attempt.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Remove the identity first: if this fails the job retries with all data
+	// intact, whereas purging first would lose track of who to delete.
+	if err := w.UserDeleter.DeleteUser(ctx, user.ClerkID); err != nil {
+		return fmt.Errorf("failed to delete identity: %w", err)
+	}
+
+	var unschedule []models.Reminder
+	err = w.GormDB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		unschedule, err = PurgeUser(tx, int64(user.ID))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to purge user: %w", err)
+	}
+
+	riverClient := river.ClientFromContext[pgx.Tx](ctx)
+	for _, reminder := range unschedule {
+		UnscheduleReminder(ctx, riverClient, reminder)
+	}
+	return nil
+}
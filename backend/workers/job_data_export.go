@@ -0,0 +1,171 @@
+package workers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"reminder-app/config"
+	"reminder-app/lib/mail"
+	"reminder-app/models"
+	"time"
+
+	"github.com/riverqueue/river"
+	"gorm.io/gorm"
+)
+
+// DataExportTTL is how long a finished archive can be downloaded.
+const DataExportTTL = 7 * 24 * time.Hour
+
+type DataExportJobArgs struct {
+	ExportID int64 `json:"export_id"`
+}
+
+func (DataExportJobArgs) Kind() string { return "data_export" }
+
+// DataExportWorker builds the archive for a "download my data" request and
+// emails the user once it's ready.
+type DataExportWorker struct {
+	river.WorkerDefaults[DataExportJobArgs]
+	GormDB      *gorm.DB
+	EmailSender mail.Sender
+	Config      *config.Config
+}
+
+func (w *DataExportWorker) Work(ctx context.Context, job *river.Job[DataExportJobArgs]) error {
+	var export models.DataExport
+	err := w.GormDB.Where("id = ?", job.Args.ExportID).First(&export).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The account was deleted in the meantime.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get export: %w", err)
+	}
+
+	archive, err := w.buildArchive(export.UserID)
+	if err != nil {
+		if job.Attempt < job.MaxAttempts {
+			return err
+		}
+		w.GormDB.Model(&export).Updates(map[string]any{
+			"status": models.DataExportStatusFailed,
+			"error":  err.Error(),
+		})
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(DataExportTTL)
+	err = w.GormDB.Model(&export).Updates(map[string]any{
+		"status":       models.DataExportStatusReady,
+		"archive":      archive,
+		"error":        "",
+		"completed_at": now,
+		"expires_at":   expiresAt,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to save export: %w", err)
+	}
+
+	if err := w.notify(export.UserID); err != nil {
+		log.Printf("Failed to notify user %d of export %d: %v", export.UserID, export.ID, err)
+	}
+	return nil
+}
+
+// buildArchive zips one JSON file per kind of data held about the user.
+func (w *DataExportWorker) buildArchive(userID int64) ([]byte, error) {
+	var user models.User
+	if err := w.GormDB.Where("id = ?", userID).First(&user).Error; err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var contactMethodIDs []int64
+	if err := w.GormDB.Model(&models.ContactMethod{}).Where("user_id = ?", userID).Pluck("id", &contactMethodIDs).Error; err != nil {
+		return nil, err
+	}
+	reminders := w.GormDB.Model(&models.Reminder{}).Select("id").Where("user_id = ?", userID)
+
+	files := []struct {
+		name  string
+		query func(any) error
+		dest  any
+	}{
+		{"contact_methods.json", w.find("user_id = ?", userID), &[]models.ContactMethod{}},
+		{"reminders.json", func(dest any) error {
+			return w.GormDB.Where("user_id = ?", userID).Preload("RotationSlots").Order("id").Find(dest).Error
+		}, &[]models.Reminder{}},
+		{"subscriptions.json", w.find("user_id = ?", userID), &[]models.ReminderSubscription{}},
+		{"households.json", w.find("id IN (?)", w.GormDB.Model(&models.HouseholdMember{}).Select("household_id").Where("user_id = ?", userID)), &[]models.Household{}},
+		{"household_memberships.json", w.find("user_id = ?", userID), &[]models.HouseholdMember{}},
+		{"invitations.json", w.find("invited_by_user_id = ? OR invitee_user_id = ?", userID, userID), &[]models.Invitation{}},
+		{"deliveries.json", w.find("reminder_id IN (?) OR contact_method_id IN ?", reminders, orNone(contactMethodIDs)), &[]models.Delivery{}},
+		{"api_tokens.json", w.find("user_id = ?", userID), &[]models.APIToken{}},
+		{"calendar_feeds.json", w.find("user_id = ?", userID), &[]models.CalendarFeed{}},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := writeZipJSON(zw, "user.json", user); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if err := file.query(file.dest); err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", file.name, err)
+		}
+		if err := writeZipJSON(zw, file.name, file.dest); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (w *DataExportWorker) find(query string, args ...any) func(any) error {
+	return func(dest any) error {
+		return w.GormDB.Where(query, args...).Order("id").Find(dest).Error
+	}
+}
+
+func writeZipJSON(zw *zip.Writer, name string, v any) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// notify emails the user's email addresses a link to the download.
+func (w *DataExportWorker) notify(userID int64) error {
+	var contactMethods []models.ContactMethod
+	err := w.GormDB.Where("user_id = ? AND type = ?", userID, models.ContactTypeEmail).Find(&contactMethods).Error
+	if err != nil {
+		return err
+	}
+
+	link := w.Config.App.BaseURL + "/settings"
+	body := fmt.Sprintf(`
+	<html>
+		<body>
+			<p style="font-size: 16px;">Your data export is ready.</p>
+			<p><a href="%s">Download it from your settings</a></p>
+			<p style="color: #666;">The download is available for 7 days.</p>
+		</body>
+	</html>
+	`, html.EscapeString(link))
+
+	var errs []error
+	for _, contactMethod := range contactMethods {
+		errs = append(errs, w.EmailSender.Send(contactMethod.Value, "Your data export is ready", body))
+	}
+	return errors.Join(errs...)
+}
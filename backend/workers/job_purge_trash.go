@@ -0,0 +1,75 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"reminder-app/config"
+	"reminder-app/models"
+	"time"
+
+	"github.com/riverqueue/river"
+	"gorm.io/gorm"
+)
+
+const purgeTrashBatchSize = 500
+
+type PurgeTrashJobArgs struct{}
+
+func (PurgeTrashJobArgs) Kind() string { return "purge_trash" }
+
+// NewPurgeTrashJob runs the purge hourly.
+func NewPurgeTrashJob() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(time.Hour),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return PurgeTrashJobArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
+
+// PurgeTrashWorker hard-deletes reminders that have been in the trash for
+// longer than the configured retention, and data exports and Telegram link
+// codes past their expiry.
+type PurgeTrashWorker struct {
+	river.WorkerDefaults[PurgeTrashJobArgs]
+	GormDB *gorm.DB
+	Config *config.Config
+}
+
+func (w *PurgeTrashWorker) Work(ctx context.Context, job *river.Job[PurgeTrashJobArgs]) error {
+	if err := w.GormDB.Unscoped().Where("expires_at < ?", time.Now()).Delete(&models.DataExport{}).Error; err != nil {
+		return fmt.Errorf("failed to purge data exports: %w", err)
+	}
+	if err := w.GormDB.Unscoped().Where("expires_at < ?", time.Now()).Delete(&models.TelegramLinkCode{}).Error; err != nil {
+		return fmt.Errorf("failed to purge telegram link codes: %w", err)
+	}
+
+	retention := w.Config.App.TrashRetention
+	if retention <= 0 {
+		return nil
+	}
+	cutoff := time.Now().Add(-retention)
+
+	for {
+		var ids []int64
+		err := w.GormDB.Unscoped().Model(&models.Reminder{}).
+			Where("deleted_at < ?", cutoff).
+			Limit(purgeTrashBatchSize).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return fmt.Errorf("failed to find trashed reminders: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		// Trashed reminders were unscheduled when they were deleted.
+		err = w.GormDB.Transaction(func(tx *gorm.DB) error {
+			return DeleteReminders(tx, ids)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to purge trashed reminders: %w", err)
+		}
+	}
+}
@@ -2,9 +2,19 @@ package workers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"reminder-app/config"
+	"reminder-app/controller/protocol"
+	"reminder-app/lib/channel"
 	"reminder-app/lib/mail"
+	"reminder-app/lib/reply"
+	"reminder-app/lib/webpush"
 	"reminder-app/models"
+	"strings"
+	"time"
 
 	"github.com/riverqueue/river"
 	"gorm.io/gorm"
@@ -12,6 +22,9 @@ import (
 
 type ReminderJobArgs struct {
 	ReminderID int `json:"reminder_id"`
+	// DeliveryID is set for a snoozed delivery, which is sent again to the
+	// same contact method unless it has been acknowledged.
+	DeliveryID int64 `json:"delivery_id,omitempty"`
 }
 
 func (ReminderJobArgs) Kind() string { return "reminder" }
@@ -23,10 +36,23 @@ type EmailSender interface {
 type ReminderJobWorker struct {
 	river.WorkerDefaults[ReminderJobArgs]
 	GormDB      *gorm.DB
+	Config      *config.Config
 	EmailSender mail.Sender
+	PushSender  *webpush.Sender
+	// ReplyAddresses is nil when replies to reminder emails can't be received.
+	ReplyAddresses *reply.Addresses
+	// Channels send to the contact types they're keyed by.
+	Channels map[string]channel.Sender
 }
 
+// maxRateLimitSnoozes is how many times a job waits out a rate limit before
+// it fails, and River's own retries and backoff take over.
+const maxRateLimitSnoozes = 5
+
 func (w *ReminderJobWorker) Work(ctx context.Context, job *river.Job[ReminderJobArgs]) error {
+	if job.Args.DeliveryID != 0 {
+		return w.redeliver(ctx, job.Args.DeliveryID)
+	}
 
 	var reminder models.Reminder
 	err := w.GormDB.Model(&reminder).Where("id = ?", job.Args.ReminderID).First(&reminder).Error
@@ -34,26 +60,228 @@ func (w *ReminderJobWorker) Work(ctx context.Context, job *river.Job[ReminderJob
 		return fmt.Errorf("failed to get reminder: %w", err)
 	}
 
+	// Skipped occurrences, and any that were already queued when the reminder
+	// was paused, don't deliver or hand the rotation on.
+	if reminder.PausedAt != nil || IsExcluded(reminder, job.ScheduledAt) {
+		return nil
+	}
+
 	var contactMethod models.ContactMethod
 	err = w.GormDB.Model(&contactMethod).Where("id = ?", reminder.ContactMethodID).First(&contactMethod).Error
 	if err != nil {
 		return fmt.Errorf("failed to get contact method: %w", err)
 	}
 
-	body := fmt.Sprintf(`
+	body := emailBody(reminder)
+
+	if err := w.deliver(ctx, reminder, job.ScheduledAt, contactMethod, body); err != nil {
+		var rateLimited *channel.RateLimitedError
+		if errors.As(err, &rateLimited) && job.Attempt <= maxRateLimitSnoozes {
+			return river.JobSnooze(rateLimited.RetryAfter)
+		}
+		return err
+	}
+
+	// People the reminder was shared with get it on their own contact methods.
+	var subscriberContactMethods []models.ContactMethod
+	err = w.GormDB.
+		Where("id IN (?)", w.GormDB.Model(&models.ReminderSubscription{}).Select("contact_method_id").Where("reminder_id = ?", reminder.ID)).
+		Find(&subscriberContactMethods).Error
+	if err != nil {
+		return fmt.Errorf("failed to get subscriber contact methods: %w", err)
+	}
+	for _, subscriberContactMethod := range subscriberContactMethods {
+		if err := w.deliver(ctx, reminder, job.ScheduledAt, subscriberContactMethod, body); err != nil {
+			log.Printf("Failed to deliver reminder %d to contact method %d: %v", reminder.ID, subscriberContactMethod.ID, err)
+		}
+	}
+
+	// Hand a rotating chore to the next assignee for the following occurrence.
+	return w.GormDB.Transaction(func(tx *gorm.DB) error {
+		if err := AdvanceRotation(tx, int64(reminder.ID), 1); err != nil {
+			return err
+		}
+		return PublishReminderEvent(tx, protocol.ReminderDelivered, reminder)
+	})
+}
+
+// redeliver sends a snoozed delivery again, unless it has been acknowledged
+// or its reminder or contact method is gone or paused since.
+func (w *ReminderJobWorker) redeliver(ctx context.Context, deliveryID int64) error {
+	var snoozed models.Delivery
+	if err := w.GormDB.First(&snoozed, deliveryID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get snoozed delivery: %w", err)
+	}
+	if snoozed.AcknowledgedAt != nil {
+		return nil
+	}
+
+	var reminder models.Reminder
+	var contactMethod models.ContactMethod
+	err := w.GormDB.First(&reminder, snoozed.ReminderID).Error
+	if err == nil {
+		err = w.GormDB.First(&contactMethod, snoozed.ContactMethodID).Error
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) || reminder.PausedAt != nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get snoozed reminder: %w", err)
+	}
+
+	return w.deliver(ctx, reminder, snoozed.CreatedAt, contactMethod, emailBody(reminder))
+}
+
+// replyHint is added to reminder emails that can be replied to.
+const replyHint = `	<p style="color: #6b7280;">Reply "done", or "snooze" and how long, such as "snooze 1h".</p>
+		`
+
+func emailBody(reminder models.Reminder) string {
+	return fmt.Sprintf(`
 	<html>
 		<body>
 			<p style="font-size: 20px;">%s</p>
 		</body>
 	</html>
 	`, reminder.Body)
+}
 
+// deliver sends the occurrence of the reminder at at and records the attempt
+// in its delivery history. The delivery is recorded first, so that replies to
+// the message can refer to it.
+func (w *ReminderJobWorker) deliver(ctx context.Context, reminder models.Reminder, at time.Time, contactMethod models.ContactMethod, body string) error {
+	// Recipients who opted out mustn't be sent anything, and retrying
+	// wouldn't change that.
+	if contactMethod.DisabledAt != nil {
+		log.Printf("Skipping delivery of reminder %d to contact method %d, which opted out", reminder.ID, contactMethod.ID)
+		return nil
+	}
+
+	delivery := models.Delivery{
+		ReminderID:      int64(reminder.ID),
+		ContactMethodID: int64(contactMethod.ID),
+		Status:          models.DeliveryStatusSent,
+	}
+	if err := w.GormDB.Create(&delivery).Error; err != nil {
+		log.Printf("Failed to record delivery of reminder %d: %v", reminder.ID, err)
+	}
+
+	err := w.send(ctx, reminder, at, contactMethod, int64(delivery.ID), body)
+	if err != nil && delivery.ID != 0 {
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.Error = err.Error()
+		if err := w.GormDB.Model(&delivery).Select("status", "error").Updates(&delivery).Error; err != nil {
+			log.Printf("Failed to record failed delivery of reminder %d: %v", reminder.ID, err)
+		}
+	}
+	return err
+}
+
+func (w *ReminderJobWorker) send(ctx context.Context, reminder models.Reminder, at time.Time, contactMethod models.ContactMethod, deliveryID int64, body string) error {
 	switch contactMethod.Type {
 	case "email":
-		return w.EmailSender.Send(contactMethod.Value, "Reminder", body)
-	case "phone":
-		fmt.Println("Phone number:", contactMethod.Value)
+		if w.ReplyAddresses == nil || deliveryID == 0 {
+			return w.EmailSender.Send(contactMethod.Value, "Reminder", body)
+		}
+		body = strings.Replace(body, "</body>", replyHint+"</body>", 1)
+		return w.EmailSender.SendWithReplyTo(contactMethod.Value, w.ReplyAddresses.ForDelivery(deliveryID), "Reminder", body)
+	case models.ContactTypePush:
+		return w.sendPush(ctx, reminder, contactMethod)
+	default:
+		sender, ok := w.Channels[contactMethod.Type]
+		if !ok {
+			return fmt.Errorf("%s isn't configured on this server", contactMethod.Type)
+		}
+		return sender.Send(ctx, contactMethod.Value, w.channelMessage(reminder, at, deliveryID))
+	}
+}
+
+// snoozeOptions are offered as buttons by services that have them.
+var snoozeOptions = []time.Duration{15 * time.Minute, time.Hour}
+
+// channelMessage renders an occurrence for chat and notification services,
+// with links back to the app and, once the delivery is recorded, buttons to
+// reply with.
+func (w *ReminderJobWorker) channelMessage(reminder models.Reminder, at time.Time, deliveryID int64) channel.Message {
+	message := channel.Message{
+		Title:    "Reminder",
+		Body:     reminder.Body,
+		Schedule: DescribeSchedule(reminder),
+		At:       at,
+		Links: []channel.Link{
+			{Label: "Open reminders", URL: w.Config.App.BaseURL},
+			{Label: "Change where reminders go", URL: w.Config.App.BaseURL + "/settings"},
+		},
+		Priority: priority(reminder),
+		Tags:     reminder.Tags,
+	}
+	if deliveryID != 0 {
+		message.Actions = append(message.Actions, channel.Action{
+			Label: "Done",
+			Data:  reply.ButtonData(deliveryID, reply.Command{Action: reply.Done}),
+		})
+		for _, d := range snoozeOptions {
+			message.Actions = append(message.Actions, channel.Action{
+				Label: "Snooze " + reply.FormatDuration(d),
+				Data:  reply.ButtonData(deliveryID, reply.Command{Action: reply.Snooze, Snooze: d}),
+			})
+		}
 	}
+	return message
+}
+
+// priority is read from the reminder's tags: "urgent", "important" or "low".
+func priority(reminder models.Reminder) channel.Priority {
+	level := channel.PriorityDefault
+	for _, tag := range reminder.Tags {
+		switch strings.ToLower(tag) {
+		case "urgent":
+			return channel.PriorityUrgent
+		case "important":
+			level = channel.PriorityHigh
+		case "low":
+			if level == channel.PriorityDefault {
+				level = channel.PriorityLow
+			}
+		}
+	}
+	return level
+}
+
+// sendPush shows the reminder as a browser notification. A subscription the
+// push service reports gone is deleted, since it will never work again.
+func (w *ReminderJobWorker) sendPush(ctx context.Context, reminder models.Reminder, contactMethod models.ContactMethod) error {
+	if w.PushSender == nil {
+		return errors.New("push notifications aren't configured")
+	}
+	sub, err := webpush.ParseSubscription(contactMethod.Value)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(PushNotification{
+		Title:      "Reminder",
+		Body:       reminder.Body,
+		ReminderID: int64(reminder.ID),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = w.PushSender.Send(ctx, sub, payload)
+	if errors.Is(err, webpush.ErrGone) {
+		if deleteErr := w.GormDB.Delete(&contactMethod).Error; deleteErr != nil {
+			log.Printf("Failed to delete expired push subscription %d: %v", contactMethod.ID, deleteErr)
+		}
+	}
+	return err
+}
 
-	return nil
+// PushNotification is the payload the frontend's service worker shows.
+type PushNotification struct {
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	ReminderID int64  `json:"reminder_id"`
 }
@@ -0,0 +1,69 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reminder-app/controller/protocol"
+	"reminder-app/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"gorm.io/gorm"
+)
+
+type ResumeReminderJobArgs struct {
+	ReminderID int64 `json:"reminder_id"`
+}
+
+func (ResumeReminderJobArgs) Kind() string { return "resume_reminder" }
+
+// ResumeReminderWorker ends a pause that was given an end time.
+type ResumeReminderWorker struct {
+	river.WorkerDefaults[ResumeReminderJobArgs]
+	GormDB *gorm.DB
+}
+
+func (w *ResumeReminderWorker) Work(ctx context.Context, job *river.Job[ResumeReminderJobArgs]) error {
+	var reminder models.Reminder
+	err := w.GormDB.Where("id = ?", job.Args.ReminderID).First(&reminder).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get reminder: %w", err)
+	}
+
+	// The reminder may have been resumed by hand, or paused again since.
+	if reminder.PausedUntil == nil || reminder.PausedUntil.After(time.Now()) {
+		return nil
+	}
+	return ResumeReminder(ctx, w.GormDB, river.ClientFromContext[pgx.Tx](ctx), &reminder)
+}
+
+// ResumeReminder ends the reminder's pause and schedules its next occurrence.
+// Occurrences missed while it was paused aren't delivered: a repeating
+// reminder picks up from the next one, and a one-time reminder whose time has
+// passed stays unscheduled.
+func ResumeReminder(ctx context.Context, db *gorm.DB, riverClient *river.Client[pgx.Tx], reminder *models.Reminder) error {
+	reminder.PausedAt = nil
+	reminder.PausedUntil = nil
+	reminder.RiverJobID = 0
+	if reminder.IsRepeating || reminder.StartTime.After(time.Now()) {
+		if err := ScheduleReminder(ctx, riverClient, reminder); err != nil {
+			return err
+		}
+	}
+
+	err := db.Model(reminder).Select("paused_at", "paused_until", "river_job_id").Updates(reminder).Error
+	if err != nil {
+		UnscheduleReminder(ctx, riverClient, *reminder)
+		return err
+	}
+	if err := PublishReminderEvent(db, protocol.ReminderUpdated, *reminder); err != nil {
+		log.Printf("Failed to publish resume of reminder %d: %v", reminder.ID, err)
+	}
+	return nil
+}
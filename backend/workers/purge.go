@@ -0,0 +1,225 @@
+package workers
+
+import (
+	"reminder-app/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+// PurgeUser hard-deletes a user and everything that belongs to them within
+// tx, returning the live reminders whose jobs must be unscheduled once tx
+// commits.
+//
+// Households the user was the only member of are deleted along with their
+// reminders. In shared households the user's reminders pass to an owner
+// (promoting a member if the user was the last owner) and the user is dropped
+// from rotations; reminders that can no longer reach anyone are deleted.
+func PurgeUser(tx *gorm.DB, userID int64) ([]models.Reminder, error) {
+	var contactMethodIDs []int64
+	if err := tx.Unscoped().Model(&models.ContactMethod{}).Where("user_id = ?", userID).Pluck("id", &contactMethodIDs).Error; err != nil {
+		return nil, err
+	}
+
+	deletedHouseholdIDs, err := leaveHouseholds(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var purged []models.Reminder
+	err = tx.Unscoped().
+		Where("user_id = ? AND household_id IS NULL", userID).
+		Or("household_id IN ?", orNone(deletedHouseholdIDs)).
+		Find(&purged).Error
+	if err != nil {
+		return nil, err
+	}
+
+	emptied, err := removeFromRotations(tx, contactMethodIDs, reminderIDs(purged))
+	if err != nil {
+		return nil, err
+	}
+	purged = append(purged, emptied...)
+
+	// Anything still aimed at the user's contact methods has no one else to go to.
+	var stranded []models.Reminder
+	err = tx.Unscoped().
+		Where("contact_method_id IN ?", orNone(contactMethodIDs)).
+		Where("id NOT IN ?", orNone(reminderIDs(purged))).
+		Find(&stranded).Error
+	if err != nil {
+		return nil, err
+	}
+	purged = append(purged, stranded...)
+
+	if err := DeleteReminders(tx, reminderIDs(purged)); err != nil {
+		return nil, err
+	}
+
+	contactMethodIDs = orNone(contactMethodIDs)
+	deletedHouseholdIDs = orNone(deletedHouseholdIDs)
+
+	deletes := []struct {
+		model any
+		query string
+		args  []any
+	}{
+		{&models.Delivery{}, "contact_method_id IN ?", []any{contactMethodIDs}},
+		{&models.ReminderSubscription{}, "user_id = ? OR contact_method_id IN ?", []any{userID, contactMethodIDs}},
+		{&models.Invitation{}, "invited_by_user_id = ? OR invitee_user_id = ? OR household_id IN ?", []any{userID, userID, deletedHouseholdIDs}},
+		{&models.ContactMethod{}, "user_id = ?", []any{userID}},
+		{&models.APIToken{}, "user_id = ?", []any{userID}},
+		{&models.CalendarFeed{}, "user_id = ?", []any{userID}},
+		{&models.DataExport{}, "user_id = ?", []any{userID}},
+		{&models.TelegramLinkCode{}, "user_id = ?", []any{userID}},
+		{&models.HouseholdMember{}, "user_id = ? OR household_id IN ?", []any{userID, deletedHouseholdIDs}},
+		{&models.Household{}, "id IN ?", []any{deletedHouseholdIDs}},
+		{&models.User{}, "id = ?", []any{userID}},
+	}
+	for _, d := range deletes {
+		if err := tx.Unscoped().Where(d.query, d.args...).Delete(d.model).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	// Soft-deleted reminders were unscheduled when they were deleted, and their
+	// stale periodic handles may since have been reused.
+	live := slices.DeleteFunc(purged, func(r models.Reminder) bool {
+		return r.DeletedAt.Valid
+	})
+	return live, nil
+}
+
+// DeleteReminders hard-deletes reminders along with their rotations,
+// subscriptions, invitations and delivery history. It doesn't touch their jobs.
+func DeleteReminders(tx *gorm.DB, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	for _, model := range []any{&models.Delivery{}, &models.ReminderSubscription{}, &models.RotationSlot{}, &models.Invitation{}} {
+		if err := tx.Unscoped().Where("reminder_id IN ?", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&models.Reminder{}).Error
+}
+
+// leaveHouseholds ends the user's memberships and returns the households that
+// are left without members, which the caller deletes.
+func leaveHouseholds(tx *gorm.DB, userID int64) ([]int64, error) {
+	var memberships []models.HouseholdMember
+	if err := tx.Where("user_id = ?", userID).Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+
+	var emptyHouseholdIDs []int64
+	for _, membership := range memberships {
+		var others []models.HouseholdMember
+		err := tx.Where("household_id = ? AND user_id <> ?", membership.HouseholdID, userID).
+			Order("created_at, id").
+			Find(&others).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(others) == 0 {
+			emptyHouseholdIDs = append(emptyHouseholdIDs, membership.HouseholdID)
+			continue
+		}
+
+		ownerIndex := slices.IndexFunc(others, func(m models.HouseholdMember) bool {
+			return m.Role == models.HouseholdRoleOwner
+		})
+		if ownerIndex < 0 {
+			// Promote the longest-standing member so the household keeps an owner.
+			ownerIndex = 0
+			if err := tx.Model(&others[0]).Update("role", models.HouseholdRoleOwner).Error; err != nil {
+				return nil, err
+			}
+		}
+
+		err = tx.Unscoped().Model(&models.Reminder{}).
+			Where("household_id = ? AND user_id = ?", membership.HouseholdID, userID).
+			Update("user_id", others[ownerIndex].UserID).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return emptyHouseholdIDs, nil
+}
+
+// removeFromRotations drops the given contact methods from every rotation
+// outside excludeIDs, keeping the current assignee where possible. It returns
+// reminders whose rotation is left empty.
+func removeFromRotations(tx *gorm.DB, contactMethodIDs []int64, excludeIDs []int64) ([]models.Reminder, error) {
+	if len(contactMethodIDs) == 0 {
+		return nil, nil
+	}
+
+	var rotating []models.Reminder
+	err := tx.Unscoped().
+		Where("id IN (?)", tx.Model(&models.RotationSlot{}).Select("reminder_id").Where("contact_method_id IN ?", contactMethodIDs)).
+		Where("id NOT IN ?", orNone(excludeIDs)).
+		Preload("RotationSlots", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Find(&rotating).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var emptied []models.Reminder
+	for _, reminder := range rotating {
+		var remaining []models.RotationSlot
+		index := 0
+		for _, slot := range reminder.RotationSlots {
+			if slices.Contains(contactMethodIDs, slot.ContactMethodID) {
+				continue
+			}
+			// If the current assignee is removed, the next remaining one is up.
+			if slot.Position < reminder.RotationIndex {
+				index++
+			}
+			remaining = append(remaining, slot)
+		}
+
+		if err := tx.Unscoped().Where("reminder_id = ? AND contact_method_id IN ?", reminder.ID, contactMethodIDs).Delete(&models.RotationSlot{}).Error; err != nil {
+			return nil, err
+		}
+		if len(remaining) == 0 {
+			emptied = append(emptied, reminder)
+			continue
+		}
+
+		for i, slot := range remaining {
+			if err := tx.Model(&models.RotationSlot{}).Where("id = ?", slot.ID).Update("position", i).Error; err != nil {
+				return nil, err
+			}
+		}
+		index %= len(remaining)
+		err := tx.Unscoped().Model(&models.Reminder{}).Where("id = ?", reminder.ID).Updates(map[string]any{
+			"rotation_index":    index,
+			"contact_method_id": remaining[index].ContactMethodID,
+		}).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return emptied, nil
+}
+
+func reminderIDs(reminders []models.Reminder) []int64 {
+	ids := make([]int64, 0, len(reminders))
+	for _, reminder := range reminders {
+		ids = append(ids, int64(reminder.ID))
+	}
+	return ids
+}
+
+// orNone keeps IN clauses valid for empty lists. IDs start at 1, so 0 never
+// matches a row.
+func orNone(ids []int64) []int64 {
+	if len(ids) == 0 {
+		return []int64{0}
+	}
+	return ids
+}
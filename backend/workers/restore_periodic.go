@@ -3,7 +3,6 @@ package workers
 import (
 	"log"
 	"reminder-app/models"
-	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/riverqueue/river"
@@ -12,16 +11,16 @@ import (
 
 func RestorePeriodicJobs(db *gorm.DB, riverClient *river.Client[pgx.Tx]) error {
 	var reminders []models.Reminder
-	err := db.Where("is_repeating AND deleted_at IS NULL").Find(&reminders).Error
+	err := db.Where("is_repeating AND period_minutes > 0 AND deleted_at IS NULL AND paused_at IS NULL").Find(&reminders).Error
 	if err != nil {
 		return err
 	}
 
 	for _, reminder := range reminders {
-		reminderJob := newPeriodicReminderJob(reminder)
+		reminderJob := NewPeriodicReminderJob(reminder)
 		handle := riverClient.PeriodicJobs().Add(reminderJob)
-		if err := db.Model(&reminder).Update("job_id", int(handle)).Error; err != nil {
-			log.Printf("Failed to update job_id for reminder %d: %v", reminder.ID, err)
+		if err := db.Model(&reminder).Update("river_job_id", int(handle)).Error; err != nil {
+			log.Printf("Failed to update river_job_id for reminder %d: %v", reminder.ID, err)
 			continue
 		}
 	}
@@ -29,15 +28,13 @@ func RestorePeriodicJobs(db *gorm.DB, riverClient *river.Client[pgx.Tx]) error {
 	return nil
 }
 
-func newPeriodicReminderJob(reminder models.Reminder) *river.PeriodicJob {
+func NewPeriodicReminderJob(reminder models.Reminder) *river.PeriodicJob {
 	return river.NewPeriodicJob(
-		river.PeriodicInterval(time.Duration(reminder.PeriodMinutes)*time.Minute),
+		NewReminderSchedule(reminder),
 		func() (river.JobArgs, *river.InsertOpts) {
 			return ReminderJobArgs{
-					ReminderID: int(reminder.ID),
-				}, &river.InsertOpts{
-					ScheduledAt: reminder.StartTime,
-				}
+				ReminderID: int(reminder.ID),
+			}, nil
 		},
 		nil,
 	)
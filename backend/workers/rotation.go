@@ -0,0 +1,32 @@
+package workers
+
+import (
+	"reminder-app/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AdvanceRotation moves a rotating reminder steps assignees forward and points
+// its contact method at the new current assignee. It is a no-op for reminders
+// without a rotation. Call it inside a transaction so the row lock holds.
+func AdvanceRotation(tx *gorm.DB, reminderID int64, steps int) error {
+	var reminder models.Reminder
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("RotationSlots", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		First(&reminder, reminderID).Error
+	if err != nil {
+		return err
+	}
+	if len(reminder.RotationSlots) == 0 {
+		return nil
+	}
+
+	index := (reminder.RotationIndex + steps) % len(reminder.RotationSlots)
+	return tx.Model(&reminder).Updates(map[string]any{
+		"rotation_index":    index,
+		"contact_method_id": reminder.RotationSlots[index].ContactMethodID,
+	}).Error
+}
@@ -0,0 +1,213 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reminder-app/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+var _ river.PeriodicSchedule = ReminderSchedule{}
+
+// ReminderSchedule fires at Start and then every Period after it. Anchoring on
+// Start keeps occurrences from drifting when the periodic job is re-added
+// (on restart, or when a reminder is edited).
+type ReminderSchedule struct {
+	Start  time.Time
+	Period time.Duration
+}
+
+func NewReminderSchedule(reminder models.Reminder) ReminderSchedule {
+	return ReminderSchedule{
+		Start:  reminder.StartTime,
+		Period: time.Duration(reminder.PeriodMinutes) * time.Minute,
+	}
+}
+
+// never is after any occurrence, for schedules that don't fire again.
+var never = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// Next returns the first occurrence strictly after current. A schedule
+// without a positive Period fires only at Start, as River's enqueuer would
+// otherwise fire it whenever Next is in the past.
+func (s ReminderSchedule) Next(current time.Time) time.Time {
+	if current.Before(s.Start) {
+		return s.Start
+	}
+	if s.Period <= 0 {
+		return never
+	}
+	elapsed := current.Sub(s.Start)
+	return s.Start.Add((elapsed/s.Period + 1) * s.Period)
+}
+
+// Occurrences expands a reminder into the times it fires in [from, to), up to
+// limit occurrences. One-time reminders occur once, at StartTime. Nothing
+// occurs while a reminder is paused.
+func Occurrences(reminder models.Reminder, from time.Time, to time.Time, limit int) []time.Time {
+	var occurrences []time.Time
+	if reminder.PausedAt != nil {
+		if reminder.PausedUntil == nil {
+			return occurrences
+		}
+		from = latest(from, *reminder.PausedUntil)
+	}
+	if !reminder.IsRepeating || reminder.PeriodMinutes <= 0 {
+		if !reminder.StartTime.Before(from) && reminder.StartTime.Before(to) && limit > 0 {
+			occurrences = append(occurrences, reminder.StartTime)
+		}
+		return occurrences
+	}
+
+	schedule := NewReminderSchedule(reminder)
+	// Next is exclusive, so step back a nanosecond to include an occurrence at from.
+	for t := schedule.Next(from.Add(-time.Nanosecond)); t.Before(to) && len(occurrences) < limit; t = schedule.Next(t) {
+		if !IsExcluded(reminder, t) {
+			occurrences = append(occurrences, t)
+		}
+	}
+	return occurrences
+}
+
+// IsExcluded reports whether the occurrence at t was removed from the
+// reminder's schedule. Times are compared to the minute since that is the
+// schedule's resolution.
+func IsExcluded(reminder models.Reminder, t time.Time) bool {
+	for _, excluded := range reminder.ExcludedTimes {
+		if excluded.Truncate(time.Minute).Equal(t.Truncate(time.Minute)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScheduleReminder queues the reminder's job and records its ID (or periodic
+// handle) in RiverJobID. The caller saves the reminder. Paused reminders are
+// left unscheduled until they're resumed.
+func ScheduleReminder(ctx context.Context, riverClient *river.Client[pgx.Tx], reminder *models.Reminder) error {
+	if reminder.PausedAt != nil {
+		return nil
+	}
+
+	if reminder.IsRepeating {
+		// Rows saved before the period was validated can't be scheduled.
+		if reminder.PeriodMinutes <= 0 {
+			log.Printf("Not scheduling reminder %d, which repeats without a period", reminder.ID)
+			return nil
+		}
+		// This adds a handle in memory, not in the database
+		handle := riverClient.PeriodicJobs().Add(NewPeriodicReminderJob(*reminder))
+		reminder.RiverJobID = int(handle)
+		return nil
+	}
+
+	args, opts := oneTimeReminderJob(*reminder)
+	insertResult, err := riverClient.Insert(ctx, args, opts)
+	if err != nil {
+		return err
+	}
+
+	reminder.RiverJobID = int(insertResult.Job.ID)
+	return nil
+}
+
+// ScheduleReminderTx inserts a one-time reminder's job in tx, so the job only
+// exists if tx commits. Repeating reminders are left to ScheduleReminder, as
+// their periodic jobs live in memory and can't be rolled back.
+func ScheduleReminderTx(ctx context.Context, riverClient *river.Client[pgx.Tx], tx pgx.Tx, reminder *models.Reminder) error {
+	if reminder.PausedAt != nil || reminder.IsRepeating {
+		return nil
+	}
+
+	args, opts := oneTimeReminderJob(*reminder)
+	insertResult, err := riverClient.InsertTx(ctx, tx, args, opts)
+	if err != nil {
+		return err
+	}
+
+	reminder.RiverJobID = int(insertResult.Job.ID)
+	return nil
+}
+
+func oneTimeReminderJob(reminder models.Reminder) (ReminderJobArgs, *river.InsertOpts) {
+	args := ReminderJobArgs{
+		ReminderID: int(reminder.ID),
+	}
+	opts := &river.InsertOpts{
+		ScheduledAt: reminder.StartTime,
+	}
+	return args, opts
+}
+
+// UnscheduleReminder stops the reminder's job from running again.
+func UnscheduleReminder(ctx context.Context, riverClient *river.Client[pgx.Tx], reminder models.Reminder) {
+	// A paused reminder's job was removed when it was paused, and its periodic
+	// handle may since have been reused.
+	if reminder.PausedAt != nil {
+		return
+	}
+
+	if reminder.IsRepeating {
+		riverClient.PeriodicJobs().Remove(rivertype.PeriodicJobHandle(reminder.RiverJobID))
+	} else {
+		riverClient.JobCancel(ctx, int64(reminder.RiverJobID))
+	}
+}
+
+// UnscheduleReminderTx cancels a one-time reminder's job in tx. Repeating
+// reminders are left to UnscheduleReminder.
+func UnscheduleReminderTx(ctx context.Context, riverClient *river.Client[pgx.Tx], tx pgx.Tx, reminder models.Reminder) error {
+	if reminder.PausedAt != nil || reminder.IsRepeating {
+		return nil
+	}
+
+	_, err := riverClient.JobCancelTx(ctx, tx, int64(reminder.RiverJobID))
+	if errors.Is(err, river.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func latest(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// DescribeSchedule says how often a reminder repeats, in the largest unit
+// its period divides into, such as "Every 2 hours".
+func DescribeSchedule(reminder models.Reminder) string {
+	if !reminder.IsRepeating || reminder.PeriodMinutes <= 0 {
+		return "One time"
+	}
+
+	units := []struct {
+		name    string
+		minutes int64
+	}{
+		{"week", 7 * 24 * 60},
+		{"day", 24 * 60},
+		{"hour", 60},
+		{"minute", 1},
+	}
+	unit := units[len(units)-1]
+	for _, u := range units {
+		if reminder.PeriodMinutes%u.minutes == 0 {
+			unit = u
+			break
+		}
+	}
+
+	count := reminder.PeriodMinutes / unit.minutes
+	if count == 1 {
+		return "Every " + unit.name
+	}
+	return fmt.Sprintf("Every %d %ss", count, unit.name)
+}
@@ -1,8 +1,21 @@
 package workers
 
 import (
+	"net/http"
 	"reminder-app/config"
-	"reminder-app/lib/mail/resend"
+	"reminder-app/lib/auth"
+	"reminder-app/lib/channel"
+	"reminder-app/lib/channel/discord"
+	"reminder-app/lib/channel/gotify"
+	"reminder-app/lib/channel/ntfy"
+	"reminder-app/lib/channel/slack"
+	"reminder-app/lib/channel/telegram"
+	"reminder-app/lib/channel/twilio"
+	"reminder-app/lib/mail"
+	"reminder-app/lib/reply"
+	"reminder-app/lib/webpush"
+	"reminder-app/models"
+	"time"
 
 	"github.com/riverqueue/river"
 	"go.uber.org/fx"
@@ -12,22 +25,59 @@ import (
 type Params struct {
 	fx.In
 
-	DB     *gorm.DB
-	Config *config.Config
+	DB          *gorm.DB
+	EmailSender mail.Sender
+	// PushSender is nil when push notifications aren't configured.
+	PushSender *webpush.Sender
+	// Telegram is nil when Telegram isn't configured.
+	Telegram *telegram.Bot
+	// ReplyAddresses is nil when inbound mail isn't configured.
+	ReplyAddresses *reply.Addresses
+	UserDeleter    auth.UserDeleter
+	Config         *config.Config
 }
 
 func New(p Params) *river.Workers {
 	workers := river.NewWorkers()
 
+	httpClient := &http.Client{Timeout: 30 * time.Second}
 	reminderWorker := &ReminderJobWorker{
-		GormDB: p.DB,
-		EmailSender: &resend.ResendSender{
-			ApiKey: p.Config.Resend.ApiKey,
-			Domain: p.Config.Resend.Domain,
+		GormDB:         p.DB,
+		Config:         p.Config,
+		EmailSender:    p.EmailSender,
+		PushSender:     p.PushSender,
+		ReplyAddresses: p.ReplyAddresses,
+		Channels: map[string]channel.Sender{
+			models.ContactTypeSlack:   slack.New(httpClient),
+			models.ContactTypeDiscord: discord.New(httpClient),
+			models.ContactTypeNtfy:    ntfy.New(httpClient, p.Config.Ntfy.ServerURL),
+			models.ContactTypeGotify:  gotify.New(httpClient),
 		},
 	}
+	if p.Telegram != nil {
+		reminderWorker.Channels[models.ContactTypeTelegram] = p.Telegram
+	}
+	if twilioConfig := p.Config.Twilio; twilioConfig.AccountSID != "" && twilioConfig.AuthToken != "" && twilioConfig.FromNumber != "" {
+		reminderWorker.Channels[models.ContactTypePhone] = twilio.New(httpClient, twilioConfig.AccountSID, twilioConfig.AuthToken, twilioConfig.FromNumber)
+	}
 
 	river.AddWorker(workers, reminderWorker)
+	river.AddWorker(workers, &DataExportWorker{
+		GormDB:      p.DB,
+		EmailSender: p.EmailSender,
+		Config:      p.Config,
+	})
+	river.AddWorker(workers, &ResumeReminderWorker{
+		GormDB: p.DB,
+	})
+	river.AddWorker(workers, &PurgeTrashWorker{
+		GormDB: p.DB,
+		Config: p.Config,
+	})
+	river.AddWorker(workers, &AccountDeletionWorker{
+		GormDB:      p.DB,
+		UserDeleter: p.UserDeleter,
+	})
 
 	return workers
 }
@@ -0,0 +1,24 @@
+// Shows reminders delivered over Web Push. The payload is the backend's
+// workers.PushNotification.
+self.addEventListener("push", (event) => {
+  const notification = event.data ? event.data.json() : {};
+  event.waitUntil(
+    self.registration.showNotification(notification.title ?? "Reminder", {
+      body: notification.body,
+      tag: `reminder-${notification.reminder_id}`,
+      data: notification,
+    })
+  );
+});
+
+self.addEventListener("notificationclick", (event) => {
+  event.notification.close();
+  event.waitUntil(
+    self.clients.matchAll({ type: "window" }).then((windows) => {
+      if (windows.length > 0) {
+        return windows[0].focus();
+      }
+      return self.clients.openWindow("/");
+    })
+  );
+});
@@ -5,6 +5,7 @@ import { QueryClient, QueryClientProvider } from "@tanstack/react-query";
 import { Toaster } from "sonner";
 import { CurrentTimeProvider } from "./contexts/CurrentTimeContext";
 import ClerkSignedInComponent from "./components/ClerkSignedInComponent";
+import LiveUpdates from "./components/LiveUpdates";
 import { initAxios } from "./api";
 
 const queryClient = new QueryClient();
@@ -21,6 +22,7 @@ function App() {
         <ClerkSignedInComponent>
           <div className="min-h-screen bg-gray-50">
             <NavBar refetchReminders={refetchReminders} />
+            <LiveUpdates onChange={refetchReminders} />
             <Outlet />
             <Toaster richColors position="bottom-right" />
           </div>
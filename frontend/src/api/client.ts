@@ -0,0 +1,937 @@
+// Code generated by generate-client. DO NOT EDIT.
+
+import axios from "axios";
+import type {
+  APIToken,
+  AcceptInvitationRequest,
+  Backup,
+  BatchRemindersRequest,
+  BatchRemindersResponse,
+  CalendarFeed,
+  ContactMethod,
+  CreateAPITokenRequest,
+  CreateAPITokenResponse,
+  CreateContactMethodRequest,
+  CreateHouseholdInvitationRequest,
+  CreateHouseholdRequest,
+  CreatePushSubscriptionRequest,
+  CreateReminderRequest,
+  DataExport,
+  DeleteResponse,
+  EmailInbox,
+  ErrorResponse,
+  ExportQuery,
+  GetRemindersQuery,
+  Household,
+  HouseholdMember,
+  ImportICSQuery,
+  ImportICSResponse,
+  ImportQuery,
+  ImportResult,
+  Invitation,
+  Occurrence,
+  OccurrencesQuery,
+  PauseReminderRequest,
+  PushPublicKey,
+  Reminder,
+  ReminderEvent,
+  ReminderPage,
+  ShareReminderRequest,
+  SwapRotationRequest,
+  TelegramLink,
+  UpdateContactMethodRequest,
+  UpdateHouseholdMemberRequest,
+  UpdateHouseholdRequest,
+  UpdateReminderRequest,
+} from "../types/protocol";
+
+// API_PREFIX is the path of the API version this client was generated for.
+export const API_PREFIX = "/api/v1";
+
+export interface ApiSuccess<T> {
+  ok: true;
+  status: number;
+  data: T;
+  // etag is sent with versioned resources; pass it back as ifMatch.
+  etag?: string;
+}
+
+export interface ApiFailure<S extends number> {
+  ok: false;
+  status: S;
+  error: ErrorResponse;
+}
+
+// ApiResult is the endpoint's response or one of the errors it documents.
+// Any other status, such as a 500, throws an UnexpectedResponseError.
+export type ApiResult<T, S extends number> = ApiSuccess<T> | ApiFailure<S>;
+
+export class UnexpectedResponseError extends Error {
+  constructor(readonly status: number, readonly error?: ErrorResponse) {
+    super(error?.error ?? `unexpected response status ${status}`);
+  }
+}
+
+export class ApiRequestError<S extends number> extends Error {
+  constructor(readonly failure: ApiFailure<S>) {
+    super(failure.error.error);
+  }
+}
+
+// unwrap returns the data of a successful result and throws the error of a
+// failed one, for callers that don't handle errors individually.
+export const unwrap = <T, S extends number>(result: ApiResult<T, S>): T => {
+  if (!result.ok) {
+    throw new ApiRequestError(result);
+  }
+  return result.data;
+};
+
+interface ApiRequest {
+  method: string;
+  url: string;
+  params?: object;
+  data?: unknown;
+  headers?: Record<string, string>;
+  // blob requests binary responses, which are parsed if they turn out to be JSON.
+  blob?: boolean;
+  errors: readonly number[];
+}
+
+const send = async <T, S extends number>(
+  request: ApiRequest
+): Promise<ApiResult<T, S>> => {
+  const response = await axios.request({
+    method: request.method,
+    url: request.url,
+    params: request.params,
+    data: request.data,
+    headers: request.headers,
+    responseType: request.blob ? "blob" : "json",
+    validateStatus: () => true,
+  });
+
+  let data = response.data;
+  const contentType = String(response.headers["content-type"] ?? "");
+  if (data instanceof Blob && contentType.startsWith("application/json")) {
+    data = JSON.parse(await data.text());
+  }
+
+  if (response.status >= 200 && response.status < 300) {
+    const etag = response.headers["etag"];
+    return {
+      ok: true,
+      status: response.status,
+      data,
+      etag: typeof etag === "string" ? etag : undefined,
+    };
+  }
+  if (request.errors.includes(response.status)) {
+    return { ok: false, status: response.status as S, error: data };
+  }
+  throw new UnexpectedResponseError(response.status, data);
+};
+
+// stream reads a text/event-stream response, passing the data of each event
+// to onEvent, and resolves when the server ends the stream or signal aborts
+// it. Events sent while no stream is open are lost, so refetch whatever
+// depends on them whenever a stream starts.
+const stream = async <T, S extends number>(
+  request: ApiRequest,
+  onEvent: (event: T) => void,
+  signal?: AbortSignal
+): Promise<ApiResult<void, S>> => {
+  // Only the fetch adapter can hand over the body before it has all arrived.
+  const response = await axios.request({
+    method: request.method,
+    url: request.url,
+    params: request.params,
+    headers: { Accept: "text/event-stream" },
+    adapter: "fetch",
+    responseType: "stream",
+    signal,
+    validateStatus: () => true,
+  });
+  const body = response.data as ReadableStream<Uint8Array>;
+
+  if (response.status < 200 || response.status >= 300) {
+    const error = await new Response(body).json().catch(() => undefined);
+    if (request.errors.includes(response.status)) {
+      return { ok: false, status: response.status as S, error };
+    }
+    throw new UnexpectedResponseError(response.status, error);
+  }
+
+  const reader = body.pipeThrough(new TextDecoderStream()).getReader();
+  let buffer = "";
+  let data: string[] = [];
+  try {
+    for (;;) {
+      const { done, value } = await reader.read();
+      if (done) {
+        break;
+      }
+      buffer += value;
+      const lines = buffer.split("\n");
+      buffer = lines.pop() ?? "";
+      for (const line of lines.map((line) => line.replace(/\r$/, ""))) {
+        if (line === "") {
+          // A blank line ends an event; comments and keep-alives have no data.
+          if (data.length > 0) {
+            onEvent(JSON.parse(data.join("\n")));
+          }
+          data = [];
+        } else if (line.startsWith("data:")) {
+          data.push(line.slice("data:".length).replace(/^ /, ""));
+        }
+      }
+    }
+  } catch (err) {
+    if (!signal?.aborted) {
+      throw err;
+    }
+  }
+  return { ok: true, status: response.status, data: undefined };
+};
+
+const upload = (file: Blob): FormData => {
+  const form = new FormData();
+  form.append("file", file);
+  return form;
+};
+
+const getRemindersErrors = [400, 401, 403] as const;
+export type GetRemindersError = (typeof getRemindersErrors)[number];
+
+// List reminders, a page at a time.
+export const getReminders = (
+  query: GetRemindersQuery
+): Promise<ApiResult<ReminderPage, GetRemindersError>> =>
+  send<ReminderPage, GetRemindersError>({
+    method: "GET",
+    url: `/reminders`,
+    params: query,
+    errors: getRemindersErrors,
+  });
+
+const createReminderErrors = [400, 401, 403] as const;
+export type CreateReminderError = (typeof createReminderErrors)[number];
+
+// Create a reminder.
+export const createReminder = (
+  body: CreateReminderRequest
+): Promise<ApiResult<Reminder, CreateReminderError>> =>
+  send<Reminder, CreateReminderError>({
+    method: "POST",
+    url: `/reminders`,
+    data: body,
+    errors: createReminderErrors,
+  });
+
+const batchRemindersErrors = [400, 401, 403, 409] as const;
+export type BatchRemindersError = (typeof batchRemindersErrors)[number];
+
+// Apply several reminder operations in one transaction.
+export const batchReminders = (
+  body: BatchRemindersRequest
+): Promise<ApiResult<BatchRemindersResponse, BatchRemindersError>> =>
+  send<BatchRemindersResponse, BatchRemindersError>({
+    method: "POST",
+    url: `/reminders/batch`,
+    data: body,
+    errors: batchRemindersErrors,
+  });
+
+const getReminderErrors = [400, 401, 403, 404] as const;
+export type GetReminderError = (typeof getReminderErrors)[number];
+
+// Get a reminder.
+export const getReminder = (
+  id: number
+): Promise<ApiResult<Reminder, GetReminderError>> =>
+  send<Reminder, GetReminderError>({
+    method: "GET",
+    url: `/reminders/${id}`,
+    errors: getReminderErrors,
+  });
+
+const updateReminderErrors = [400, 401, 403, 404, 412] as const;
+export type UpdateReminderError = (typeof updateReminderErrors)[number];
+
+// Replace a reminder.
+export const updateReminder = (
+  id: number,
+  body: UpdateReminderRequest,
+  ifMatch?: string
+): Promise<ApiResult<Reminder, UpdateReminderError>> =>
+  send<Reminder, UpdateReminderError>({
+    method: "PUT",
+    url: `/reminders/${id}`,
+    data: body,
+    headers: { ...(ifMatch ? { "If-Match": ifMatch } : {}) },
+    errors: updateReminderErrors,
+  });
+
+const patchReminderErrors = [400, 401, 403, 404, 412, 415] as const;
+export type PatchReminderError = (typeof patchReminderErrors)[number];
+
+// Change some fields of a reminder with a JSON Merge Patch.
+export const patchReminder = (
+  id: number,
+  body: { [key: string]: any },
+  ifMatch?: string
+): Promise<ApiResult<Reminder, PatchReminderError>> =>
+  send<Reminder, PatchReminderError>({
+    method: "PATCH",
+    url: `/reminders/${id}`,
+    data: body,
+    headers: { "Content-Type": "application/merge-patch+json", ...(ifMatch ? { "If-Match": ifMatch } : {}) },
+    errors: patchReminderErrors,
+  });
+
+const deleteReminderErrors = [400, 401, 403, 404] as const;
+export type DeleteReminderError = (typeof deleteReminderErrors)[number];
+
+// Move a reminder to the trash.
+export const deleteReminder = (
+  id: number
+): Promise<ApiResult<DeleteResponse, DeleteReminderError>> =>
+  send<DeleteResponse, DeleteReminderError>({
+    method: "DELETE",
+    url: `/reminders/${id}`,
+    errors: deleteReminderErrors,
+  });
+
+const getTrashErrors = [401, 403] as const;
+export type GetTrashError = (typeof getTrashErrors)[number];
+
+// List reminders in the trash.
+export const getTrash = (): Promise<ApiResult<Reminder[], GetTrashError>> =>
+  send<Reminder[], GetTrashError>({
+    method: "GET",
+    url: `/reminders/trash`,
+    errors: getTrashErrors,
+  });
+
+const restoreReminderErrors = [400, 401, 403, 404] as const;
+export type RestoreReminderError = (typeof restoreReminderErrors)[number];
+
+// Restore a reminder from the trash.
+export const restoreReminder = (
+  id: number
+): Promise<ApiResult<Reminder, RestoreReminderError>> =>
+  send<Reminder, RestoreReminderError>({
+    method: "POST",
+    url: `/reminders/${id}/restore`,
+    errors: restoreReminderErrors,
+  });
+
+const pauseReminderErrors = [400, 401, 403, 404] as const;
+export type PauseReminderError = (typeof pauseReminderErrors)[number];
+
+// Pause a reminder until a time, or indefinitely.
+export const pauseReminder = (
+  id: number,
+  body: PauseReminderRequest = {}
+): Promise<ApiResult<Reminder, PauseReminderError>> =>
+  send<Reminder, PauseReminderError>({
+    method: "POST",
+    url: `/reminders/${id}/pause`,
+    data: body,
+    errors: pauseReminderErrors,
+  });
+
+const resumeReminderErrors = [400, 401, 403, 404] as const;
+export type ResumeReminderError = (typeof resumeReminderErrors)[number];
+
+// Resume a paused reminder.
+export const resumeReminder = (
+  id: number
+): Promise<ApiResult<Reminder, ResumeReminderError>> =>
+  send<Reminder, ResumeReminderError>({
+    method: "POST",
+    url: `/reminders/${id}/resume`,
+    errors: resumeReminderErrors,
+  });
+
+const getOccurrencesErrors = [400, 401, 403, 404] as const;
+export type GetOccurrencesError = (typeof getOccurrencesErrors)[number];
+
+// List upcoming occurrences of a reminder.
+export const getOccurrences = (
+  id: number,
+  query: OccurrencesQuery = {}
+): Promise<ApiResult<Occurrence[], GetOccurrencesError>> =>
+  send<Occurrence[], GetOccurrencesError>({
+    method: "GET",
+    url: `/reminders/${id}/occurrences`,
+    params: query,
+    errors: getOccurrencesErrors,
+  });
+
+const skipRotationErrors = [400, 401, 403, 404] as const;
+export type SkipRotationError = (typeof skipRotationErrors)[number];
+
+// Hand a rotating reminder to the next assignee.
+export const skipRotation = (
+  id: number
+): Promise<ApiResult<Reminder, SkipRotationError>> =>
+  send<Reminder, SkipRotationError>({
+    method: "POST",
+    url: `/reminders/${id}/rotation/skip`,
+    errors: skipRotationErrors,
+  });
+
+const swapRotationErrors = [400, 401, 403, 404] as const;
+export type SwapRotationError = (typeof swapRotationErrors)[number];
+
+// Swap two positions in a reminder's rotation.
+export const swapRotation = (
+  id: number,
+  body: SwapRotationRequest
+): Promise<ApiResult<Reminder, SwapRotationError>> =>
+  send<Reminder, SwapRotationError>({
+    method: "POST",
+    url: `/reminders/${id}/rotation/swap`,
+    data: body,
+    errors: swapRotationErrors,
+  });
+
+const shareReminderErrors = [400, 401, 403, 404] as const;
+export type ShareReminderError = (typeof shareReminderErrors)[number];
+
+// Invite someone to receive a reminder.
+export const shareReminder = (
+  id: number,
+  body: ShareReminderRequest
+): Promise<ApiResult<Invitation, ShareReminderError>> =>
+  send<Invitation, ShareReminderError>({
+    method: "POST",
+    url: `/reminders/${id}/shares`,
+    data: body,
+    errors: shareReminderErrors,
+  });
+
+const unsubscribeReminderErrors = [400, 401, 403, 404] as const;
+export type UnsubscribeReminderError = (typeof unsubscribeReminderErrors)[number];
+
+// Stop receiving a reminder shared with you.
+export const unsubscribeReminder = (
+  id: number
+): Promise<ApiResult<DeleteResponse, UnsubscribeReminderError>> =>
+  send<DeleteResponse, UnsubscribeReminderError>({
+    method: "DELETE",
+    url: `/reminders/${id}/subscription`,
+    errors: unsubscribeReminderErrors,
+  });
+
+const importICSErrors = [400, 401, 403] as const;
+export type ImportICSError = (typeof importICSErrors)[number];
+
+// Preview or import reminders from an iCalendar file.
+export const importICS = (
+  file: Blob,
+  query: ImportICSQuery = {}
+): Promise<ApiResult<ImportICSResponse, ImportICSError>> =>
+  send<ImportICSResponse, ImportICSError>({
+    method: "POST",
+    url: `/import/ics`,
+    data: upload(file),
+    params: query,
+    errors: importICSErrors,
+  });
+
+const exportBackupErrors = [400, 401, 403] as const;
+export type ExportBackupError = (typeof exportBackupErrors)[number];
+
+// Export contact methods and reminders as JSON or CSV.
+export const exportBackup = (
+  query: ExportQuery = {}
+): Promise<ApiResult<Backup | Blob, ExportBackupError>> =>
+  send<Backup | Blob, ExportBackupError>({
+    method: "GET",
+    url: `/export`,
+    params: query,
+    blob: true,
+    errors: exportBackupErrors,
+  });
+
+const importBackupErrors = [400, 401, 403] as const;
+export type ImportBackupError = (typeof importBackupErrors)[number];
+
+// Import contact methods and reminders from an export.
+export const importBackup = (
+  body: Backup | Blob,
+  query: ImportQuery = {}
+): Promise<ApiResult<ImportResult, ImportBackupError>> =>
+  send<ImportResult, ImportBackupError>({
+    method: "POST",
+    url: `/import`,
+    data: body instanceof Blob ? upload(body) : body,
+    params: query,
+    errors: importBackupErrors,
+  });
+
+const getAgendaErrors = [400, 401, 403] as const;
+export type GetAgendaError = (typeof getAgendaErrors)[number];
+
+// List upcoming occurrences of every reminder.
+export const getAgenda = (
+  query: OccurrencesQuery = {}
+): Promise<ApiResult<Occurrence[], GetAgendaError>> =>
+  send<Occurrence[], GetAgendaError>({
+    method: "GET",
+    url: `/agenda`,
+    params: query,
+    errors: getAgendaErrors,
+  });
+
+const getContactMethodsErrors = [401, 403] as const;
+export type GetContactMethodsError = (typeof getContactMethodsErrors)[number];
+
+// List contact methods.
+export const getContactMethods = (): Promise<ApiResult<ContactMethod[], GetContactMethodsError>> =>
+  send<ContactMethod[], GetContactMethodsError>({
+    method: "GET",
+    url: `/contact-methods`,
+    errors: getContactMethodsErrors,
+  });
+
+const createContactMethodErrors = [400, 401, 403] as const;
+export type CreateContactMethodError = (typeof createContactMethodErrors)[number];
+
+// Add a contact method.
+export const createContactMethod = (
+  body: CreateContactMethodRequest
+): Promise<ApiResult<ContactMethod, CreateContactMethodError>> =>
+  send<ContactMethod, CreateContactMethodError>({
+    method: "POST",
+    url: `/contact-methods`,
+    data: body,
+    errors: createContactMethodErrors,
+  });
+
+const getContactMethodErrors = [400, 401, 403, 404] as const;
+export type GetContactMethodError = (typeof getContactMethodErrors)[number];
+
+// Get a contact method.
+export const getContactMethod = (
+  id: number
+): Promise<ApiResult<ContactMethod, GetContactMethodError>> =>
+  send<ContactMethod, GetContactMethodError>({
+    method: "GET",
+    url: `/contact-methods/${id}`,
+    errors: getContactMethodErrors,
+  });
+
+const updateContactMethodErrors = [400, 401, 403, 404, 412] as const;
+export type UpdateContactMethodError = (typeof updateContactMethodErrors)[number];
+
+// Replace a contact method.
+export const updateContactMethod = (
+  id: number,
+  body: UpdateContactMethodRequest,
+  ifMatch?: string
+): Promise<ApiResult<ContactMethod, UpdateContactMethodError>> =>
+  send<ContactMethod, UpdateContactMethodError>({
+    method: "PUT",
+    url: `/contact-methods/${id}`,
+    data: body,
+    headers: { ...(ifMatch ? { "If-Match": ifMatch } : {}) },
+    errors: updateContactMethodErrors,
+  });
+
+const patchContactMethodErrors = [400, 401, 403, 404, 412, 415] as const;
+export type PatchContactMethodError = (typeof patchContactMethodErrors)[number];
+
+// Change some fields of a contact method with a JSON Merge Patch.
+export const patchContactMethod = (
+  id: number,
+  body: { [key: string]: any },
+  ifMatch?: string
+): Promise<ApiResult<ContactMethod, PatchContactMethodError>> =>
+  send<ContactMethod, PatchContactMethodError>({
+    method: "PATCH",
+    url: `/contact-methods/${id}`,
+    data: body,
+    headers: { "Content-Type": "application/merge-patch+json", ...(ifMatch ? { "If-Match": ifMatch } : {}) },
+    errors: patchContactMethodErrors,
+  });
+
+const deleteContactMethodErrors = [400, 401, 403, 404] as const;
+export type DeleteContactMethodError = (typeof deleteContactMethodErrors)[number];
+
+// Delete a contact method.
+export const deleteContactMethod = (
+  id: number
+): Promise<ApiResult<DeleteResponse, DeleteContactMethodError>> =>
+  send<DeleteResponse, DeleteContactMethodError>({
+    method: "DELETE",
+    url: `/contact-methods/${id}`,
+    errors: deleteContactMethodErrors,
+  });
+
+const getPushPublicKeyErrors = [401, 403, 503] as const;
+export type GetPushPublicKeyError = (typeof getPushPublicKeyErrors)[number];
+
+// Get the key browsers subscribe to push notifications with.
+export const getPushPublicKey = (): Promise<ApiResult<PushPublicKey, GetPushPublicKeyError>> =>
+  send<PushPublicKey, GetPushPublicKeyError>({
+    method: "GET",
+    url: `/push-subscriptions/public-key`,
+    errors: getPushPublicKeyErrors,
+  });
+
+const createPushSubscriptionErrors = [400, 401, 403, 503] as const;
+export type CreatePushSubscriptionError = (typeof createPushSubscriptionErrors)[number];
+
+// Save a browser's push subscription as a push contact method.
+export const createPushSubscription = (
+  body: CreatePushSubscriptionRequest
+): Promise<ApiResult<ContactMethod, CreatePushSubscriptionError>> =>
+  send<ContactMethod, CreatePushSubscriptionError>({
+    method: "POST",
+    url: `/push-subscriptions`,
+    data: body,
+    errors: createPushSubscriptionErrors,
+  });
+
+const createTelegramLinkErrors = [401, 403, 503] as const;
+export type CreateTelegramLinkError = (typeof createTelegramLinkErrors)[number];
+
+// Get a link that connects a Telegram chat as a contact method.
+export const createTelegramLink = (): Promise<ApiResult<TelegramLink, CreateTelegramLinkError>> =>
+  send<TelegramLink, CreateTelegramLinkError>({
+    method: "POST",
+    url: `/telegram/link`,
+    errors: createTelegramLinkErrors,
+  });
+
+const getEmailInboxErrors = [401, 403, 503] as const;
+export type GetEmailInboxError = (typeof getEmailInboxErrors)[number];
+
+// Get the address you can email new reminders to.
+export const getEmailInbox = (): Promise<ApiResult<EmailInbox, GetEmailInboxError>> =>
+  send<EmailInbox, GetEmailInboxError>({
+    method: "GET",
+    url: `/email-inbox`,
+    errors: getEmailInboxErrors,
+  });
+
+const getHouseholdsErrors = [401, 403] as const;
+export type GetHouseholdsError = (typeof getHouseholdsErrors)[number];
+
+// List your households.
+export const getHouseholds = (): Promise<ApiResult<Household[], GetHouseholdsError>> =>
+  send<Household[], GetHouseholdsError>({
+    method: "GET",
+    url: `/households`,
+    errors: getHouseholdsErrors,
+  });
+
+const createHouseholdErrors = [400, 401, 403] as const;
+export type CreateHouseholdError = (typeof createHouseholdErrors)[number];
+
+// Create a household.
+export const createHousehold = (
+  body: CreateHouseholdRequest
+): Promise<ApiResult<Household, CreateHouseholdError>> =>
+  send<Household, CreateHouseholdError>({
+    method: "POST",
+    url: `/households`,
+    data: body,
+    errors: createHouseholdErrors,
+  });
+
+const updateHouseholdErrors = [400, 401, 403, 404] as const;
+export type UpdateHouseholdError = (typeof updateHouseholdErrors)[number];
+
+// Rename a household.
+export const updateHousehold = (
+  id: number,
+  body: UpdateHouseholdRequest
+): Promise<ApiResult<Household, UpdateHouseholdError>> =>
+  send<Household, UpdateHouseholdError>({
+    method: "PUT",
+    url: `/households/${id}`,
+    data: body,
+    errors: updateHouseholdErrors,
+  });
+
+const deleteHouseholdErrors = [400, 401, 403, 404, 409] as const;
+export type DeleteHouseholdError = (typeof deleteHouseholdErrors)[number];
+
+// Delete a household without reminders.
+export const deleteHousehold = (
+  id: number
+): Promise<ApiResult<DeleteResponse, DeleteHouseholdError>> =>
+  send<DeleteResponse, DeleteHouseholdError>({
+    method: "DELETE",
+    url: `/households/${id}`,
+    errors: deleteHouseholdErrors,
+  });
+
+const getHouseholdMembersErrors = [400, 401, 403, 404] as const;
+export type GetHouseholdMembersError = (typeof getHouseholdMembersErrors)[number];
+
+// List a household's members.
+export const getHouseholdMembers = (
+  id: number
+): Promise<ApiResult<HouseholdMember[], GetHouseholdMembersError>> =>
+  send<HouseholdMember[], GetHouseholdMembersError>({
+    method: "GET",
+    url: `/households/${id}/members`,
+    errors: getHouseholdMembersErrors,
+  });
+
+const updateHouseholdMemberErrors = [400, 401, 403, 404, 409] as const;
+export type UpdateHouseholdMemberError = (typeof updateHouseholdMemberErrors)[number];
+
+// Change a member's role.
+export const updateHouseholdMember = (
+  id: number,
+  userID: number,
+  body: UpdateHouseholdMemberRequest
+): Promise<ApiResult<HouseholdMember, UpdateHouseholdMemberError>> =>
+  send<HouseholdMember, UpdateHouseholdMemberError>({
+    method: "PUT",
+    url: `/households/${id}/members/${userID}`,
+    data: body,
+    errors: updateHouseholdMemberErrors,
+  });
+
+const removeHouseholdMemberErrors = [400, 401, 403, 404, 409] as const;
+export type RemoveHouseholdMemberError = (typeof removeHouseholdMemberErrors)[number];
+
+// Remove a member, or leave a household.
+export const removeHouseholdMember = (
+  id: number,
+  userID: number
+): Promise<ApiResult<DeleteResponse, RemoveHouseholdMemberError>> =>
+  send<DeleteResponse, RemoveHouseholdMemberError>({
+    method: "DELETE",
+    url: `/households/${id}/members/${userID}`,
+    errors: removeHouseholdMemberErrors,
+  });
+
+const getHouseholdContactMethodsErrors = [400, 401, 403, 404] as const;
+export type GetHouseholdContactMethodsError = (typeof getHouseholdContactMethodsErrors)[number];
+
+// List the contact methods of a household's members.
+export const getHouseholdContactMethods = (
+  id: number
+): Promise<ApiResult<ContactMethod[], GetHouseholdContactMethodsError>> =>
+  send<ContactMethod[], GetHouseholdContactMethodsError>({
+    method: "GET",
+    url: `/households/${id}/contact-methods`,
+    errors: getHouseholdContactMethodsErrors,
+  });
+
+const getHouseholdInvitationsErrors = [400, 401, 403, 404] as const;
+export type GetHouseholdInvitationsError = (typeof getHouseholdInvitationsErrors)[number];
+
+// List a household's pending invitations.
+export const getHouseholdInvitations = (
+  id: number
+): Promise<ApiResult<Invitation[], GetHouseholdInvitationsError>> =>
+  send<Invitation[], GetHouseholdInvitationsError>({
+    method: "GET",
+    url: `/households/${id}/invitations`,
+    errors: getHouseholdInvitationsErrors,
+  });
+
+const createHouseholdInvitationErrors = [400, 401, 403, 404] as const;
+export type CreateHouseholdInvitationError = (typeof createHouseholdInvitationErrors)[number];
+
+// Invite someone to a household.
+export const createHouseholdInvitation = (
+  id: number,
+  body: CreateHouseholdInvitationRequest
+): Promise<ApiResult<Invitation, CreateHouseholdInvitationError>> =>
+  send<Invitation, CreateHouseholdInvitationError>({
+    method: "POST",
+    url: `/households/${id}/invitations`,
+    data: body,
+    errors: createHouseholdInvitationErrors,
+  });
+
+const getInvitationsErrors = [401, 403] as const;
+export type GetInvitationsError = (typeof getInvitationsErrors)[number];
+
+// List pending invitations addressed to you.
+export const getInvitations = (): Promise<ApiResult<Invitation[], GetInvitationsError>> =>
+  send<Invitation[], GetInvitationsError>({
+    method: "GET",
+    url: `/invitations`,
+    errors: getInvitationsErrors,
+  });
+
+const acceptInvitationErrors = [400, 401, 403, 404, 409] as const;
+export type AcceptInvitationError = (typeof acceptInvitationErrors)[number];
+
+// Accept an invitation.
+export const acceptInvitation = (
+  body: AcceptInvitationRequest
+): Promise<ApiResult<Invitation, AcceptInvitationError>> =>
+  send<Invitation, AcceptInvitationError>({
+    method: "POST",
+    url: `/invitations/accept`,
+    data: body,
+    errors: acceptInvitationErrors,
+  });
+
+const revokeInvitationErrors = [400, 401, 403, 404] as const;
+export type RevokeInvitationError = (typeof revokeInvitationErrors)[number];
+
+// Revoke an invitation.
+export const revokeInvitation = (
+  id: number
+): Promise<ApiResult<DeleteResponse, RevokeInvitationError>> =>
+  send<DeleteResponse, RevokeInvitationError>({
+    method: "DELETE",
+    url: `/invitations/${id}`,
+    errors: revokeInvitationErrors,
+  });
+
+const getTokensErrors = [401, 403] as const;
+export type GetTokensError = (typeof getTokensErrors)[number];
+
+// List personal access tokens.
+export const getTokens = (): Promise<ApiResult<APIToken[], GetTokensError>> =>
+  send<APIToken[], GetTokensError>({
+    method: "GET",
+    url: `/tokens`,
+    errors: getTokensErrors,
+  });
+
+const createTokenErrors = [400, 401, 403] as const;
+export type CreateTokenError = (typeof createTokenErrors)[number];
+
+// Create a personal access token.
+export const createToken = (
+  body: CreateAPITokenRequest
+): Promise<ApiResult<CreateAPITokenResponse, CreateTokenError>> =>
+  send<CreateAPITokenResponse, CreateTokenError>({
+    method: "POST",
+    url: `/tokens`,
+    data: body,
+    errors: createTokenErrors,
+  });
+
+const deleteTokenErrors = [400, 401, 403, 404] as const;
+export type DeleteTokenError = (typeof deleteTokenErrors)[number];
+
+// Revoke a personal access token.
+export const deleteToken = (
+  id: number
+): Promise<ApiResult<DeleteResponse, DeleteTokenError>> =>
+  send<DeleteResponse, DeleteTokenError>({
+    method: "DELETE",
+    url: `/tokens/${id}`,
+    errors: deleteTokenErrors,
+  });
+
+const getCalendarFeedErrors = [401, 403, 404] as const;
+export type GetCalendarFeedError = (typeof getCalendarFeedErrors)[number];
+
+// Get your calendar feed.
+export const getCalendarFeed = (): Promise<ApiResult<CalendarFeed, GetCalendarFeedError>> =>
+  send<CalendarFeed, GetCalendarFeedError>({
+    method: "GET",
+    url: `/calendar-feed`,
+    errors: getCalendarFeedErrors,
+  });
+
+const rotateCalendarFeedErrors = [401, 403] as const;
+export type RotateCalendarFeedError = (typeof rotateCalendarFeedErrors)[number];
+
+// Create or rotate your calendar feed URL.
+export const rotateCalendarFeed = (): Promise<ApiResult<CalendarFeed, RotateCalendarFeedError>> =>
+  send<CalendarFeed, RotateCalendarFeedError>({
+    method: "POST",
+    url: `/calendar-feed`,
+    errors: rotateCalendarFeedErrors,
+  });
+
+const deleteCalendarFeedErrors = [401, 403, 404] as const;
+export type DeleteCalendarFeedError = (typeof deleteCalendarFeedErrors)[number];
+
+// Delete your calendar feed.
+export const deleteCalendarFeed = (): Promise<ApiResult<DeleteResponse, DeleteCalendarFeedError>> =>
+  send<DeleteResponse, DeleteCalendarFeedError>({
+    method: "DELETE",
+    url: `/calendar-feed`,
+    errors: deleteCalendarFeedErrors,
+  });
+
+const getDataExportsErrors = [401, 403] as const;
+export type GetDataExportsError = (typeof getDataExportsErrors)[number];
+
+// List your data exports.
+export const getDataExports = (): Promise<ApiResult<DataExport[], GetDataExportsError>> =>
+  send<DataExport[], GetDataExportsError>({
+    method: "GET",
+    url: `/account/exports`,
+    errors: getDataExportsErrors,
+  });
+
+const requestDataExportErrors = [401, 403] as const;
+export type RequestDataExportError = (typeof requestDataExportErrors)[number];
+
+// Start an export of all your data.
+export const requestDataExport = (): Promise<ApiResult<DataExport, RequestDataExportError>> =>
+  send<DataExport, RequestDataExportError>({
+    method: "POST",
+    url: `/account/exports`,
+    errors: requestDataExportErrors,
+  });
+
+const downloadDataExportErrors = [400, 401, 403, 404] as const;
+export type DownloadDataExportError = (typeof downloadDataExportErrors)[number];
+
+// Download a finished data export.
+export const downloadDataExport = (
+  id: number
+): Promise<ApiResult<Blob, DownloadDataExportError>> =>
+  send<Blob, DownloadDataExportError>({
+    method: "GET",
+    url: `/account/exports/${id}/download`,
+    blob: true,
+    errors: downloadDataExportErrors,
+  });
+
+const deleteAccountErrors = [401, 403] as const;
+export type DeleteAccountError = (typeof deleteAccountErrors)[number];
+
+// Delete your account and everything in it.
+export const deleteAccount = (): Promise<ApiResult<DeleteResponse, DeleteAccountError>> =>
+  send<DeleteResponse, DeleteAccountError>({
+    method: "DELETE",
+    url: `/account`,
+    errors: deleteAccountErrors,
+  });
+
+const streamEventsErrors = [401, 403] as const;
+export type StreamEventsError = (typeof streamEventsErrors)[number];
+
+// Stream changes to your reminders as server-sent events.
+export const streamEvents = (
+  onEvent: (event: ReminderEvent) => void,
+  signal?: AbortSignal
+): Promise<ApiResult<void, StreamEventsError>> =>
+  stream<ReminderEvent, StreamEventsError>({
+    method: "GET",
+    url: `/events`,
+    errors: streamEventsErrors,
+  }, onEvent, signal);
+
+const getOpenAPIErrors = [] as const;
+export type GetOpenAPIError = (typeof getOpenAPIErrors)[number];
+
+// Get this OpenAPI document.
+export const getOpenAPI = (): Promise<ApiResult<{ [key: string]: any }, GetOpenAPIError>> =>
+  send<{ [key: string]: any }, GetOpenAPIError>({
+    method: "GET",
+    url: `/openapi.json`,
+    errors: getOpenAPIErrors,
+  });
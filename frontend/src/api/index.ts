@@ -1,5 +1,6 @@
 import axios from "axios";
 import { API_BASE_URL } from "../constants";
+import { API_PREFIX } from "./client";
 
 type WindowWithClerk = Window & {
   Clerk?: {
@@ -9,7 +10,7 @@ type WindowWithClerk = Window & {
   };
 };
 
-export const API_URL = `${API_BASE_URL}/api`;
+export const API_URL = `${API_BASE_URL}${API_PREFIX}`;
 
 export const initAxios = () => {
   axios.interceptors.request.use(async (config) => {
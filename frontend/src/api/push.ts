@@ -0,0 +1,45 @@
+import * as client from "./client";
+import { unwrap } from "./client";
+import type { ContactMethod } from "../types/protocol";
+
+const SERVICE_WORKER_URL = "/push-sw.js";
+
+export const isPushSupported = (): boolean =>
+  "serviceWorker" in navigator && "PushManager" in window;
+
+// subscribeToPush asks for permission to show notifications, subscribes this
+// browser and saves the subscription as a push contact method.
+export const subscribeToPush = async (
+  description: string
+): Promise<ContactMethod> => {
+  const permission = await Notification.requestPermission();
+  if (permission !== "granted") {
+    throw new Error("Notifications are blocked for this site");
+  }
+
+  const { public_key } = unwrap(await client.getPushPublicKey());
+  const registration =
+    await navigator.serviceWorker.register(SERVICE_WORKER_URL);
+  const subscription = await registration.pushManager.subscribe({
+    userVisibleOnly: true,
+    applicationServerKey: decodeBase64Url(public_key),
+  });
+
+  const { endpoint, keys } = subscription.toJSON();
+  if (!endpoint || !keys?.p256dh || !keys?.auth) {
+    throw new Error("The browser returned an incomplete push subscription");
+  }
+  return unwrap(
+    await client.createPushSubscription({
+      endpoint,
+      keys: { p256dh: keys.p256dh, auth: keys.auth },
+      description,
+    })
+  );
+};
+
+const decodeBase64Url = (value: string): Uint8Array => {
+  const base64 = value.replace(/-/g, "+").replace(/_/g, "/");
+  const padded = base64 + "=".repeat((4 - (base64.length % 4)) % 4);
+  return Uint8Array.from(atob(padded), (c) => c.charCodeAt(0));
+};
@@ -1,4 +1,5 @@
-import axios from "axios";
+import * as client from "./client";
+import { unwrap } from "./client";
 import type {
   Reminder,
   CreateReminderRequest,
@@ -9,64 +10,137 @@ import type {
   DeleteResponse,
   ErrorResponse,
   GetRemindersQuery,
+  ReminderPage,
+  OccurrencesQuery,
+  Occurrence,
+  ImportICSQuery,
+  ImportICSResponse,
+  PauseReminderRequest,
+  BatchRemindersRequest,
+  BatchRemindersResponse,
 } from "../types/protocol";
 
+// These wrap the generated client in ./client and throw an ApiRequestError on
+// failure. Call the client directly to handle specific error statuses.
+
+export const getRemindersPage = async (
+  query: GetRemindersQuery
+): Promise<ReminderPage> => {
+  return unwrap(await client.getReminders(query));
+};
+
+// Follows next_cursor until every page matching the query has been loaded.
 export const getReminders = async (
   query: GetRemindersQuery
-): Promise<Reminder[] | null> => {
-  const response = await axios.get(
-    `/reminders?include_past=${query.include_past}`
-  );
-  return response.data;
+): Promise<Reminder[]> => {
+  const reminders: Reminder[] = [];
+  let cursor: string | undefined;
+  do {
+    const page = await getRemindersPage({ ...query, cursor });
+    reminders.push(...page.reminders);
+    cursor = page.next_cursor ?? undefined;
+  } while (cursor);
+  return reminders;
 };
 
 export const createReminder = async (
   reminder: CreateReminderRequest
 ): Promise<Reminder> => {
-  const response = await axios.post(`/reminders`, reminder);
-  return response.data;
+  return unwrap(await client.createReminder(reminder));
 };
 
 export const updateReminder = async (
   id: number,
   reminder: UpdateReminderRequest
 ): Promise<Reminder> => {
-  const response = await axios.put(`/reminders/${id}`, reminder);
-  return response.data;
+  return unwrap(await client.updateReminder(id, reminder));
+};
+
+// Only the fields present in patch change. Pass the ETag header of an earlier
+// response as ifMatch to get a 412 instead of overwriting someone else's edit.
+export const patchReminder = async (
+  id: number,
+  patch: Partial<UpdateReminderRequest>,
+  ifMatch?: string
+): Promise<Reminder> => {
+  return unwrap(await client.patchReminder(id, patch, ifMatch));
 };
 
 export const deleteReminder = async (id: number): Promise<DeleteResponse> => {
-  const response = await axios.delete(`/reminders/${id}`);
-  return response.data;
+  return unwrap(await client.deleteReminder(id));
+};
+
+export const pauseReminder = async (
+  id: number,
+  request: PauseReminderRequest = {}
+): Promise<Reminder> => {
+  return unwrap(await client.pauseReminder(id, request));
+};
+
+export const resumeReminder = async (id: number): Promise<Reminder> => {
+  return unwrap(await client.resumeReminder(id));
+};
+
+export const batchReminders = async (
+  request: BatchRemindersRequest
+): Promise<BatchRemindersResponse> => {
+  return unwrap(await client.batchReminders(request));
+};
+
+export const getTrash = async (): Promise<Reminder[]> => {
+  return unwrap(await client.getTrash());
+};
+
+export const restoreReminder = async (id: number): Promise<Reminder> => {
+  return unwrap(await client.restoreReminder(id));
+};
+
+export const getOccurrences = async (
+  id: number,
+  query: OccurrencesQuery = {}
+): Promise<Occurrence[]> => {
+  return unwrap(await client.getOccurrences(id, query));
+};
+
+export const getAgenda = async (
+  query: OccurrencesQuery = {}
+): Promise<Occurrence[]> => {
+  return unwrap(await client.getAgenda(query));
+};
+
+// Previews the reminders in an .ics file, or creates them when query.confirm is set.
+export const importICS = async (
+  file: File,
+  query: ImportICSQuery
+): Promise<ImportICSResponse> => {
+  return unwrap(await client.importICS(file, query));
 };
 
 export const getContactMethods = async (): Promise<ContactMethod[] | null> => {
-  const response = await axios.get(`/contact-methods`);
-  return response.data;
+  return unwrap(await client.getContactMethods());
 };
 
 export const createContactMethod = async (
   contactMethod: CreateContactMethodRequest
 ): Promise<ContactMethod> => {
-  const response = await axios.post(`/contact-methods`, contactMethod);
-  return response.data;
+  return unwrap(await client.createContactMethod(contactMethod));
 };
 
 export const updateContactMethod = async (
   id: number,
   contactMethod: UpdateContactMethodRequest
 ): Promise<ContactMethod> => {
-  const response = await axios.put(`/contact-methods/${id}`, contactMethod);
-  return response.data;
+  return unwrap(await client.updateContactMethod(id, contactMethod));
 };
 
 export const deleteContactMethod = async (
   id: number
 ): Promise<DeleteResponse> => {
-  const response = await axios.delete(`/contact-methods/${id}`);
-  return response.data;
+  return unwrap(await client.deleteContactMethod(id));
 };
 
+export { ApiRequestError } from "./client";
+
 // Re-export types for convenience
 export type {
   Reminder,
@@ -78,4 +152,12 @@ export type {
   DeleteResponse,
   ErrorResponse,
   GetRemindersQuery,
+  ReminderPage,
+  OccurrencesQuery,
+  Occurrence,
+  ImportICSQuery,
+  ImportICSResponse,
+  PauseReminderRequest,
+  BatchRemindersRequest,
+  BatchRemindersResponse,
 };
@@ -3,6 +3,7 @@ import { ContactMethod } from "../api/reminders";
 import React from "react";
 import { Card, CardContent, PhoneInput, Input, Button } from "./ui";
 import { formatPhoneNumber } from "./ui/PhoneInput";
+import { CONTACT_TYPE_OPTIONS, contactTypeOption } from "./contactTypes";
 
 interface ContactMethodCardProps {
   method: ContactMethod;
@@ -36,28 +37,17 @@ export default function ContactMethodCard({
       <Card>
         <CardContent className="space-y-3">
           <div className="flex gap-3">
-            <label className="flex items-center gap-2">
-              <input
-                type="radio"
-                checked={editedMethod.type === "email"}
-                onChange={() =>
-                  setEditedMethod({ ...editedMethod, type: "email" })
-                }
-                className="w-4 h-4 text-blue-600"
-              />
-              <span className="text-sm">Email</span>
-            </label>
-            <label className="flex items-center gap-2">
-              <input
-                type="radio"
-                checked={editedMethod.type === "phone"}
-                onChange={() =>
-                  setEditedMethod({ ...editedMethod, type: "phone" })
-                }
-                className="w-4 h-4 text-blue-600"
-              />
-              <span className="text-sm">Phone</span>
-            </label>
+            {CONTACT_TYPE_OPTIONS.map(({ type, label }) => (
+              <label key={type} className="flex items-center gap-2">
+                <input
+                  type="radio"
+                  checked={editedMethod.type === type}
+                  onChange={() => setEditedMethod({ ...editedMethod, type })}
+                  className="w-4 h-4 text-blue-600"
+                />
+                <span className="text-sm">{label}</span>
+              </label>
+            ))}
           </div>
 
           {editedMethod.type === "phone" && (
@@ -67,13 +57,20 @@ export default function ContactMethodCard({
             />
           )}
 
-          {editedMethod.type === "email" && (
+          {editedMethod.type !== "phone" && (
             <Input
               value={editedMethod.value}
               onChange={(e) =>
                 setEditedMethod({ ...editedMethod, value: e.target.value })
               }
-              type={editedMethod.type === "email" ? "email" : "tel"}
+              type={
+                editedMethod.type === "email"
+                  ? "email"
+                  : editedMethod.type === "ntfy"
+                    ? "text"
+                    : "url"
+              }
+              placeholder={contactTypeOption(editedMethod.type)?.placeholder}
             />
           )}
 
@@ -115,17 +112,33 @@ export default function ContactMethodCard({
               <span className="font-medium">
                 {method.type === "phone"
                   ? formatPhoneNumber(method.value)
-                  : method.value}
+                  : method.type === "push"
+                    ? "Browser notifications"
+                    : method.type === "telegram"
+                      ? "Telegram chat"
+                      : (contactTypeOption(method.type)?.summarize?.(
+                          method.value
+                        ) ?? method.value)}
               </span>
+              {method.disabled_at && (
+                <span
+                  className="px-2 py-1 bg-red-100 text-red-700 rounded text-xs font-medium"
+                  title="Texting START to the reminder number opts back in"
+                >
+                  Opted out
+                </span>
+              )}
             </div>
             {method.description && (
               <p className="text-sm text-gray-600 mt-1">{method.description}</p>
             )}
           </div>
           <div className="flex gap-2">
-            <Button onClick={onEdit} variant="ghost" size="sm">
-              Edit
-            </Button>
+            {method.type !== "push" && method.type !== "telegram" && (
+              <Button onClick={onEdit} variant="ghost" size="sm">
+                Edit
+              </Button>
+            )}
             <Button
               onClick={onDelete}
               variant="ghost"
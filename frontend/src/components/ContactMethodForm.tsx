@@ -7,6 +7,7 @@ import {
 } from "../api/reminders";
 import { useMutation } from "@tanstack/react-query";
 import { DEFAULT_USER_ID } from "../constants";
+import { CONTACT_TYPE_OPTIONS, contactTypeOption } from "./contactTypes";
 
 interface Props {
   onSuccess: () => void;
@@ -46,24 +47,17 @@ export default function ContactMethodForm({ onSuccess, onCancel }: Props) {
         <h3 className="font-medium">New Contact Method</h3>
 
         <div className="flex gap-3">
-          <label className="flex items-center gap-2">
-            <input
-              type="radio"
-              checked={formData.type === "email"}
-              onChange={() => setFormData({ ...formData, type: "email" })}
-              className="w-4 h-4 text-blue-600"
-            />
-            <span className="text-sm">Email</span>
-          </label>
-          <label className="flex items-center gap-2">
-            <input
-              type="radio"
-              checked={formData.type === "phone"}
-              onChange={() => setFormData({ ...formData, type: "phone" })}
-              className="w-4 h-4 text-blue-600"
-            />
-            <span className="text-sm">Phone</span>
-          </label>
+          {CONTACT_TYPE_OPTIONS.map(({ type, label }) => (
+            <label key={type} className="flex items-center gap-2">
+              <input
+                type="radio"
+                checked={formData.type === type}
+                onChange={() => setFormData({ ...formData, type })}
+                className="w-4 h-4 text-blue-600"
+              />
+              <span className="text-sm">{label}</span>
+            </label>
+          ))}
         </div>
 
         {formData.type === "phone" && (
@@ -85,6 +79,21 @@ export default function ContactMethodForm({ onSuccess, onCancel }: Props) {
             />
           </>
         )}
+
+        {contactTypeOption(formData.type) && (
+          <>
+            <label className="text-sm text-gray-700">
+              {contactTypeOption(formData.type)?.valueLabel}
+            </label>
+            <Input
+              value={formData.value}
+              onChange={(e) =>
+                setFormData({ ...formData, value: e.target.value })
+              }
+              placeholder={contactTypeOption(formData.type)?.placeholder}
+            />
+          </>
+        )}
         <>
           <label className="text-sm text-gray-700">Description</label>
           <Input
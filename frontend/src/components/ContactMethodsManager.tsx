@@ -8,6 +8,8 @@ import {
   deleteContactMethod,
   type UpdateContactMethodRequest,
 } from "../api/reminders";
+import { isPushSupported, subscribeToPush } from "../api/push";
+import { createTelegramLink, unwrap } from "../api/client";
 import { DEFAULT_USER_ID } from "../constants";
 import ContactMethodCard from "./ContactMethodCard";
 import ContactMethodForm from "./ContactMethodForm";
@@ -64,6 +66,39 @@ export default function ContactMethodsManager({
     },
   });
 
+  const pushMutation = useMutation({
+    mutationFn: () => subscribeToPush(navigator.userAgent),
+    onSuccess: () => {
+      toast.success("Browser notifications enabled");
+      refetch();
+    },
+    onError: (error) => {
+      toast.error("Failed to enable browser notifications", {
+        description: error.message,
+      });
+    },
+  });
+
+  // The bot adds the contact method once it gets the link's /start message,
+  // and the list refetches when the user comes back to this tab.
+  const telegramMutation = useMutation({
+    mutationFn: async () => unwrap(await createTelegramLink()),
+    onSuccess: ({ url }) => {
+      toast.success("Send the bot the start message to connect Telegram", {
+        action: {
+          label: "Open Telegram",
+          onClick: () => window.open(url, "_blank", "noopener"),
+        },
+        duration: 60_000,
+      });
+    },
+    onError: (error) => {
+      toast.error("Failed to connect Telegram", {
+        description: error.message,
+      });
+    },
+  });
+
   const handleDelete = (id: number) => {
     if (
       window.confirm("Are you sure you want to delete this contact method?")
@@ -80,13 +115,33 @@ export default function ContactMethodsManager({
     <div className="space-y-4">
       <div className="flex items-center justify-between">
         <h3 className="text-xl font-medium">Contact Methods</h3>
-        <Button
-          variant="outline"
-          onClick={() => setShowAddForm(true)}
-          size="sm"
-        >
-          + Contact Method
-        </Button>
+        <div className="flex gap-2">
+          {isPushSupported() && (
+            <Button
+              variant="outline"
+              onClick={() => pushMutation.mutate()}
+              size="sm"
+              disabled={pushMutation.isPending}
+            >
+              + This Browser
+            </Button>
+          )}
+          <Button
+            variant="outline"
+            onClick={() => telegramMutation.mutate()}
+            size="sm"
+            disabled={telegramMutation.isPending}
+          >
+            + Telegram
+          </Button>
+          <Button
+            variant="outline"
+            onClick={() => setShowAddForm(true)}
+            size="sm"
+          >
+            + Contact Method
+          </Button>
+        </div>
       </div>
 
       {showAddForm && (
@@ -0,0 +1,32 @@
+import { useQuery } from "@tanstack/react-query";
+import { getEmailInbox } from "../api/client";
+import { Card, CardContent } from "./ui";
+
+// EmailInbox shows the address new reminders can be emailed to, when the
+// server receives mail.
+export default function EmailInbox() {
+  const { data: result } = useQuery({
+    queryKey: ["emailInbox"],
+    queryFn: () => getEmailInbox(),
+  });
+
+  if (!result?.ok) {
+    return null;
+  }
+
+  return (
+    <Card>
+      <CardContent className="space-y-1">
+        <h3 className="font-medium">Email a reminder</h3>
+        <p className="text-sm text-gray-600">
+          Send an email from one of your email contact methods to{" "}
+          <code className="bg-gray-100 px-1 rounded break-all">
+            {result.data.address}
+          </code>{" "}
+          and its subject becomes a reminder. End the subject with a time such
+          as "in 2h"; otherwise it's due in an hour.
+        </p>
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,39 @@
+import { useEffect } from "react";
+import { streamEvents } from "../api/client";
+
+const RECONNECT_DELAY_MS = 5000;
+
+interface Props {
+  onChange: () => void;
+}
+
+// LiveUpdates calls onChange whenever a reminder changes on the server,
+// including when it's edited from another device or delivered. Events sent
+// while the stream is down are lost, so it also calls onChange each time the
+// stream reconnects.
+export default function LiveUpdates({ onChange }: Props) {
+  useEffect(() => {
+    const controller = new AbortController();
+
+    const listen = async () => {
+      let reconnecting = false;
+      while (!controller.signal.aborted) {
+        if (reconnecting) {
+          onChange();
+        }
+        reconnecting = true;
+        try {
+          await streamEvents(() => onChange(), controller.signal);
+        } catch {
+          // Reconnect below, as when the server ends the stream.
+        }
+        await new Promise((resolve) => setTimeout(resolve, RECONNECT_DELAY_MS));
+      }
+    };
+    listen();
+
+    return () => controller.abort();
+  }, [onChange]);
+
+  return null;
+}
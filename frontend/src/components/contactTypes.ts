@@ -0,0 +1,66 @@
+interface ContactTypeOption {
+  type: string;
+  label: string;
+  // valueLabel and placeholder describe the value of types that are entered
+  // as a URL or topic.
+  valueLabel?: string;
+  placeholder?: string;
+  // summarize is shown in place of values that hold a secret.
+  summarize?: (value: string) => string;
+}
+
+// hostOf shows only where a URL points, leaving out any token in it.
+const hostOf = (value: string): string => {
+  try {
+    return new URL(value).host;
+  } catch {
+    return value;
+  }
+};
+
+// Contact types that can be added by typing in a value. Push contact methods
+// are added by subscribing a browser instead.
+export const CONTACT_TYPE_OPTIONS: ContactTypeOption[] = [
+  { type: "email", label: "Email" },
+  { type: "phone", label: "Phone" },
+  {
+    type: "slack",
+    label: "Slack",
+    valueLabel: "Webhook URL",
+    placeholder: "https://hooks.slack.com/services/...",
+    summarize: () => "Incoming webhook",
+  },
+  {
+    type: "discord",
+    label: "Discord",
+    valueLabel: "Webhook URL",
+    placeholder: "https://discord.com/api/webhooks/...",
+    summarize: () => "Incoming webhook",
+  },
+  {
+    type: "ntfy",
+    label: "ntfy",
+    valueLabel: "Topic or topic URL",
+    placeholder: "your-topic or https://ntfy.example.com/your-topic",
+    summarize: (value) =>
+      value.includes("/")
+        ? `${value.replace(/\/+$/, "").split("/").pop()} on ${hostOf(value)}`
+        : value,
+  },
+  {
+    type: "gotify",
+    label: "Gotify",
+    valueLabel: "Server URL with application token",
+    placeholder: "https://gotify.example.com/?token=...",
+    summarize: hostOf,
+  },
+];
+
+// contactTypeOption returns the option for types whose value is entered as a
+// URL or topic, or undefined for the others.
+export const contactTypeOption = (
+  type: string
+): ContactTypeOption | undefined =>
+  CONTACT_TYPE_OPTIONS.find(
+    (option) => option.type === type && option.placeholder
+  );
@@ -1,10 +1,12 @@
 import React from "react";
 import ContactMethodsManager from "../../components/ContactMethodsManager";
+import EmailInbox from "../../components/EmailInbox";
 
 export default function SettingsContainer() {
   return (
-    <div className="container mx-auto px-4 py-8">
+    <div className="container mx-auto px-4 py-8 space-y-6">
       <ContactMethodsManager />
+      <EmailInbox />
     </div>
   );
 }
@@ -13,6 +13,17 @@ export interface ClerkUserCreatedEvent {
   timestamp: number /* int64 */;
   type: string;
 }
+export interface ClerkUserDeletedEvent {
+  data: ClerkDeletedObject;
+  object: string;
+  timestamp: number /* int64 */;
+  type: string;
+}
+export interface ClerkDeletedObject {
+  id: string;
+  deleted: boolean;
+  object: string;
+}
 export interface ClerkUserData {
   birthday: string;
   created_at: number /* int64 */;
@@ -63,6 +74,7 @@ export interface HTTPRequest {
 // source: protocol.go
 
 export interface CreateReminderRequest {
+  household_id?: number /* int64 */;
   body: string;
   start_time: string;
   is_repeating: boolean;
@@ -70,10 +82,21 @@ export interface CreateReminderRequest {
   contact_method_id: number /* int64 */;
   phone_number?: string;
   email?: string;
+  /**
+   * Rotation lists contact methods that take turns receiving a repeating
+   * reminder, in order. ContactMethodID is ignored when it is set.
+   */
+  rotation?: number /* int64 */[];
+  tags?: string[];
+  /**
+   * ExcludedTimes are occurrences of a repeating reminder to skip.
+   */
+  excluded_times?: string[];
 }
 export interface Reminder {
   id: number /* int64 */;
   user_id: number /* int64 */;
+  household_id?: number /* int64 */;
   body: string;
   start_time: string;
   is_repeating: boolean;
@@ -81,6 +104,67 @@ export interface Reminder {
   contact_method_id: number /* int64 */;
   phone_number?: string;
   email?: string;
+  tags: string[];
+  excluded_times: string[];
+  rotation: number /* int64 */[];
+  current_assignee?: RotationAssignee;
+  paused_at?: string;
+  paused_until?: string;
+  updated_at: string;
+  /**
+   * IsSubscribed marks someone else's reminder that was shared with the actor.
+   */
+  is_subscribed: boolean;
+  /**
+   * DeletedAt is set on reminders in the trash.
+   */
+  deleted_at?: string;
+}
+/**
+ * PauseReminderRequest pauses a reminder until Until, or indefinitely if it's
+ * omitted.
+ */
+export interface PauseReminderRequest {
+  until?: string;
+}
+/**
+ * BatchRemindersRequest applies several operations in one transaction. With
+ * Atomic, any failed operation rolls back the whole batch; otherwise only the
+ * failed operations are rolled back.
+ */
+export interface BatchRemindersRequest {
+  atomic: boolean;
+  operations: BatchReminderOperation[];
+}
+/**
+ * BatchReminderOperation is one create, update, patch, delete, pause or
+ * resume. Every op but create needs ID, and IfMatch applies to update and
+ * patch. The op's own request goes in the field of the same name.
+ */
+export interface BatchReminderOperation {
+  op: string;
+  id?: number /* int64 */;
+  if_match?: string;
+  create?: CreateReminderRequest;
+  update?: UpdateReminderRequest;
+  patch?: { [key: string]: any};
+  pause?: PauseReminderRequest;
+}
+/**
+ * BatchReminderResult reports one operation, in request order. Status is ok,
+ * error, or rolled_back for an operation that succeeded in a batch that
+ * didn't commit.
+ */
+export interface BatchReminderResult {
+  index: number /* int */;
+  status: string;
+  id?: number /* int64 */;
+  reminder?: Reminder;
+  error?: string;
+}
+export interface BatchRemindersResponse {
+  committed: boolean;
+  results: BatchReminderResult[];
 }
 export interface ContactMethod {
   id: number /* int64 */;
@@ -88,6 +172,12 @@ export interface ContactMethod {
   type: string;
   value: string;
   description: string;
+  /**
+   * DisabledAt is when the recipient opted out of messages, such as by
+   * texting STOP. It's cleared when they opt back in.
+   */
+  disabled_at?: string;
+  updated_at: string;
 }
 export interface CreateContactMethodRequest {
   type: string;
@@ -99,7 +189,43 @@ export interface UpdateContactMethodRequest {
   value: string;
   description: string;
 }
+/**
+ * CreatePushSubscriptionRequest is a browser's PushSubscription, as its
+ * toJSON serializes it, with a Description to tell devices apart.
+ */
+export interface CreatePushSubscriptionRequest {
+  endpoint: string;
+  keys: PushSubscriptionKeys;
+  description: string;
+}
+export interface PushSubscriptionKeys {
+  p256dh: string;
+  auth: string;
+}
+/**
+ * PushPublicKey is the VAPID public key browsers pass to
+ * PushManager.subscribe as applicationServerKey.
+ */
+export interface PushPublicKey {
+  public_key: string;
+}
+/**
+ * TelegramLink opens a chat with the bot that links it as a contact method.
+ * It works once, until ExpiresAt.
+ */
+export interface TelegramLink {
+  url: string;
+  expires_at: string;
+}
+/**
+ * EmailInbox is the address a user can email to create reminders: the
+ * subject is the reminder, optionally ending in when, such as "in 2h".
+ */
+export interface EmailInbox {
+  address: string;
+}
 export interface UpdateReminderRequest {
+  household_id?: number /* int64 */;
   body: string;
   start_time: string;
   is_repeating: boolean;
@@ -107,6 +233,18 @@ export interface UpdateReminderRequest {
   contact_method_id: number /* int64 */;
   phone_number?: string;
   email?: string;
+  /**
+   * Rotation lists contact methods that take turns receiving a repeating
+   * reminder, in order. ContactMethodID is ignored when it is set.
+   * Omitting Rotation, Tags, ExcludedTimes or HouseholdID leaves them
+   * unchanged; send an empty list to clear them.
+   */
+  rotation?: number /* int64 */[];
+  tags?: string[];
+  /**
+   * ExcludedTimes are occurrences of a repeating reminder to skip.
+   */
+  excluded_times?: string[];
 }
 export interface DeleteResponse {
   message: string;
@@ -116,4 +254,277 @@ export interface ErrorResponse {
 }
 export interface GetRemindersQuery {
   include_past: boolean;
+  /**
+   * Cursor is the next_cursor of the previous page.
+   */
+  cursor?: string;
+  limit?: number /* int */;
+  repeating?: boolean;
+  contact_method_id?: number /* int64 */;
+  from?: string;
+  to?: string;
+  tag?: string;
+  /**
+   * Status is "upcoming" or "past" and takes precedence over IncludePast.
+   */
+  status?: string;
+  /**
+   * Sort is start_time, created_at or updated_at, prefixed with "-" for descending.
+   */
+  sort?: string;
+  /**
+   * Q is a full-text search over the reminder body.
+   */
+  q?: string;
+}
+export interface ReminderPage {
+  reminders: Reminder[];
+  next_cursor?: string;
+}
+export interface APIToken {
+  id: number /* int64 */;
+  name: string;
+  prefix: string;
+  scopes: string[];
+  expires_at: string;
+  last_used_at?: string;
+  created_at: string;
+}
+export interface CreateAPITokenRequest {
+  name: string;
+  scopes: string[];
+  expires_in_days: number /* int */;
+}
+/**
+ * CreateAPITokenResponse is the only time the plaintext token is returned.
+ */
+export interface CreateAPITokenResponse extends APIToken {
+  token: string;
+}
+export interface Household {
+  id: number /* int64 */;
+  name: string;
+  role: string;
+}
+export interface HouseholdMember {
+  user_id: number /* int64 */;
+  name: string;
+  role: string;
+}
+export interface CreateHouseholdRequest {
+  name: string;
+}
+export interface UpdateHouseholdRequest {
+  name: string;
+}
+export interface UpdateHouseholdMemberRequest {
+  role: string;
+}
+export interface RotationAssignee {
+  position: number /* int */;
+  contact_method_id: number /* int64 */;
+  user_id: number /* int64 */;
+}
+export interface SwapRotationRequest {
+  position_a: number /* int */;
+  position_b: number /* int */;
+}
+export interface Invitation {
+  id: number /* int64 */;
+  kind: string;
+  household_id?: number /* int64 */;
+  reminder_id?: number /* int64 */;
+  role: string;
+  email: string;
+  expires_at: string;
+}
+export interface CreateHouseholdInvitationRequest {
+  email: string;
+  role: string;
+}
+export interface ShareReminderRequest {
+  email: string;
+}
+/**
+ * AcceptInvitationRequest accepts the signed token from an invitation link.
+ * Reminder shares also need the contact method to deliver the reminder to.
+ */
+export interface AcceptInvitationRequest {
+  token: string;
+  contact_method_id: number /* int64 */;
+}
+/**
+ * OccurrencesQuery bounds the expansion of reminder schedules into concrete
+ * times. From defaults to now and To to 30 days after From.
+ */
+export interface OccurrencesQuery {
+  from?: string;
+  to?: string;
+  limit?: number /* int */;
+}
+/**
+ * Occurrence is a single upcoming delivery of a reminder. ContactMethodID is
+ * the assignee at that point in a rotation.
+ */
+export interface Occurrence {
+  reminder_id: number /* int64 */;
+  body: string;
+  occurs_at: string;
+  contact_method_id: number /* int64 */;
+}
+/**
+ * CalendarFeed describes the user's iCalendar subscription. URL embeds the
+ * secret token, so it is only returned when the feed is created or rotated.
+ */
+export interface CalendarFeed {
+  prefix: string;
+  created_at: string;
+  last_fetched_at?: string;
+  url?: string;
+}
+/**
+ * ImportICSQuery configures an .ics import. Without Confirm the file is only
+ * previewed; ContactMethodID is required to create the reminders.
+ */
+export interface ImportICSQuery {
+  contact_method_id?: number /* int64 */;
+  household_id?: number /* int64 */;
+  confirm?: boolean;
+}
+/**
+ * ImportedReminder is one VEVENT or VTODO from an imported calendar and the
+ * reminder it maps to.
+ */
+export interface ImportedReminder {
+  uid: string;
+  kind: string;
+  body: string;
+  start_time: string;
+  is_repeating: boolean;
+  period_minutes: number /* int64 */;
+  excluded_times: string[];
+  warnings: string[];
+  /**
+   * Error explains why the entry is skipped.
+   */
+  error?: string;
+  /**
+   * Reminder is set once the entry has been created.
+   */
+  reminder?: Reminder;
+}
+export interface ImportICSResponse {
+  reminders: ImportedReminder[];
+  created: number /* int */;
+}
+/**
+ * ExportQuery selects the export format: json (default) holds contact
+ * methods and reminders together, csv holds one Type per file.
+ */
+export interface ExportQuery {
+  format?: string;
+  /**
+   * Type is reminders or contact_methods and is required for csv.
+   */
+  type?: string;
+}
+/**
+ * ImportQuery mirrors ExportQuery for the uploaded file. OnConflict is skip
+ * (default) or overwrite; DryRun reports what would happen without saving.
+ */
+export interface ImportQuery {
+  format?: string;
+  type?: string;
+  dry_run?: boolean;
+  on_conflict?: string;
+}
+/**
+ * Backup is the JSON export of a user's contact methods and personal
+ * reminders, and the format accepted by the import endpoint.
+ */
+export interface Backup {
+  version: number /* int */;
+  exported_at: string;
+  contact_methods: BackupContactMethod[];
+  reminders: BackupReminder[];
+}
+export interface BackupContactMethod {
+  id: number /* int64 */;
+  type: string;
+  value: string;
+  description: string;
+}
+/**
+ * BackupReminder points at its contact method by ContactMethodID, which is
+ * either an ID from the same backup or one of the importing user's contact
+ * methods, or by ContactMethodType and ContactMethodValue.
+ */
+export interface BackupReminder {
+  id: number /* int64 */;
+  body: string;
+  start_time: string;
+  is_repeating: boolean;
+  period_minutes: number /* int64 */;
+  contact_method_id?: number /* int64 */;
+  contact_method_type?: string;
+  contact_method_value?: string;
+  rotation?: number /* int64 */[];
+  tags?: string[];
+  excluded_times?: string[];
+}
+/**
+ * ImportRowResult reports what happened to one row. Row is 1-based within its
+ * section of the file. Status is created, updated, skipped or error.
+ */
+export interface ImportRowResult {
+  row: number /* int */;
+  status: string;
+  id?: number /* int64 */;
+  error?: string;
+}
+export interface ImportResult {
+  dry_run: boolean;
+  contact_methods: ImportRowResult[];
+  reminders: ImportRowResult[];
+}
+/**
+ * DataExport is a "download my data" request. Status is pending, ready or
+ * failed; a ready archive can be downloaded until ExpiresAt.
+ */
+export interface DataExport {
+  id: number /* int64 */;
+  status: string;
+  error?: string;
+  created_at: string;
+  completed_at?: string;
+  expires_at?: string;
+}
+export const ReminderCreated = "reminder.created";
+export const ReminderUpdated = "reminder.updated";
+export const ReminderDeleted = "reminder.deleted";
+export const ReminderDelivered = "reminder.delivered";
+/**
+ * ReminderEvent is streamed from /events when a reminder the user can see
+ * changes. It names the reminder rather than carrying it, so clients refetch
+ * what they display.
+ */
+export interface ReminderEvent {
+  type: string;
+  reminder_id: number /* int64 */;
+  at: string;
+}
+
+//////////
+// source: resend_protocol.go
+
+/**
+ * ResendEvent is a Resend webhook. email.received events announce mail to
+ * the inbound domain by ID, and its content is fetched separately.
+ */
+export interface ResendEvent {
+  type: string;
+  data: ResendEventData;
+}
+export interface ResendEventData {
+  email_id: string;
 }